@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/driemworks/mercury-blockchain/state"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+func genesisCmd() *cobra.Command {
+	var genesisCmd = &cobra.Command{
+		Use:   "genesis",
+		Short: "Creates the genesis of a new chain.",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return incorrectUsageErr()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+		},
+	}
+
+	genesisCmd.AddCommand(genesisNewCmd())
+
+	return genesisCmd
+}
+
+func genesisNewCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "new",
+		Short: "Writes the genesis of a new chain, to be used with 'mercury init --genesis'.",
+		Run: func(cmd *cobra.Command, args []string) {
+			genesis, err := genesisFromCmd(cmd)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			if err := genesis.Validate(); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			genesisJSON, err := json.MarshalIndent(genesis, "", "    ")
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			out, _ := cmd.Flags().GetString(flagOut)
+			if out == "" {
+				fmt.Println(string(genesisJSON))
+				return
+			}
+			if err := ioutil.WriteFile(out, append(genesisJSON, '\n'), 0644); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			hash, err := genesis.Hash()
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Printf("Genesis of chain '%s' written to %s\n", genesis.ChainID, out)
+			fmt.Printf("Genesis hash: %s\n", hash.Hex())
+		},
+	}
+
+	cmd.Flags().String(flagChainID, "", "the ID of the new chain, txs are signed for it")
+	cmd.MarkFlagRequired(flagChainID)
+	cmd.Flags().String(flagAlloc, "", "the starting balances in coins, e.g. '0xabc...:1000,0xdef...:2.5'")
+	cmd.Flags().String(flagConsensus, state.ConsensusProofOfWork, "the consensus engine: pow, poa or dev")
+	cmd.Flags().Uint64(flagDifficulty, state.DefaultDifficulty, "the proof of work difficulty of the first block")
+	cmd.Flags().Uint64(flagBlockTime, state.DefaultBlockTime, "target seconds between blocks")
+	cmd.Flags().Uint64(flagRetarget, state.DefaultRetargetInterval, "number of blocks between difficulty adjustments")
+	cmd.Flags().String(flagSigners, "", "comma separated addresses allowed to seal blocks under proof of authority")
+	cmd.Flags().String(flagReward, "10", "the reward of the first block in coins, 0 to mint no rewards so miners only earn fees")
+	cmd.Flags().Uint64(flagHalving, 0, "the reward halves every this many blocks, 0 to never halve")
+	cmd.Flags().String(flagMaxSupply, "0", "the most coins there can be, including the starting balances, 0 for no cap")
+	cmd.Flags().String(flagOut, "", "the file to write the genesis to, printed when empty")
+	return cmd
+}
+
+/*
+ Build the genesis described by the flags of 'genesis new'
+*/
+func genesisFromCmd(cmd *cobra.Command) (state.Genesis, error) {
+	chainID, _ := cmd.Flags().GetString(flagChainID)
+	consensus, _ := cmd.Flags().GetString(flagConsensus)
+	difficulty, _ := cmd.Flags().GetUint64(flagDifficulty)
+	blockTime, _ := cmd.Flags().GetUint64(flagBlockTime)
+	retargetInterval, _ := cmd.Flags().GetUint64(flagRetarget)
+	halvingInterval, _ := cmd.Flags().GetUint64(flagHalving)
+	allocFlag, _ := cmd.Flags().GetString(flagAlloc)
+	alloc, err := parseAlloc(allocFlag)
+	if err != nil {
+		return state.Genesis{}, err
+	}
+	signersFlag, _ := cmd.Flags().GetString(flagSigners)
+	signers, err := parseSigners(signersFlag)
+	if err != nil {
+		return state.Genesis{}, err
+	}
+	rewardFlag, _ := cmd.Flags().GetString(flagReward)
+	reward, err := state.ParseCoins(rewardFlag)
+	if err != nil {
+		return state.Genesis{}, err
+	}
+	maxSupplyFlag, _ := cmd.Flags().GetString(flagMaxSupply)
+	maxSupply, err := state.ParseCoins(maxSupplyFlag)
+	if err != nil {
+		return state.Genesis{}, err
+	}
+	genesis := state.Genesis{
+		Version:          state.GenesisVersion,
+		ChainID:          chainID,
+		GenesisTime:      time.Now().UTC().Format(time.RFC3339),
+		State:            alloc,
+		Consensus:        consensus,
+		Difficulty:       difficulty,
+		BlockTime:        blockTime,
+		RetargetInterval: retargetInterval,
+		Signers:          signers,
+	}
+	// a reward of 0 still needs a schedule, chains without one credit miners 10 coins per block
+	genesis.Reward = &state.RewardSchedule{Initial: reward, HalvingInterval: halvingInterval, MaxSupply: maxSupply}
+	return genesis, nil
+}
+
+/*
+ Parse a comma separated list of address:coins starting balances
+*/
+func parseAlloc(value string) (map[common.Address]state.CurrentNodeState, error) {
+	alloc := make(map[common.Address]state.CurrentNodeState)
+	if value == "" {
+		return alloc, nil
+	}
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 2 || !common.IsHexAddress(parts[0]) {
+			return nil, fmt.Errorf("invalid allocation '%s', expected address:coins", entry)
+		}
+		balance, err := state.ParseCoins(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		address := state.NewAddress(parts[0])
+		if _, ok := alloc[address]; ok {
+			return nil, fmt.Errorf("%s is allocated more than once", address.Hex())
+		}
+		alloc[address] = state.CurrentNodeState{Balance: balance}
+	}
+	return alloc, nil
+}
+
+/*
+ Parse a comma separated list of signer addresses
+*/
+func parseSigners(value string) ([]common.Address, error) {
+	if value == "" {
+		return nil, nil
+	}
+	signers := make([]common.Address, 0)
+	for _, signer := range strings.Split(value, ",") {
+		signer = strings.TrimSpace(signer)
+		if !common.IsHexAddress(signer) {
+			return nil, fmt.Errorf("invalid signer address '%s'", signer)
+		}
+		signers = append(signers, state.NewAddress(signer))
+	}
+	return signers, nil
+}
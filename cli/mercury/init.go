@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/driemworks/mercury-blockchain/state"
+
+	"github.com/spf13/cobra"
+)
+
+func initCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "init",
+		Short: "Initialises the node's data dir with the genesis of the chain it runs.",
+		Run: func(cmd *cobra.Command, args []string) {
+			genesisFile, _ := cmd.Flags().GetString(flagGenesis)
+			var genesis state.Genesis
+			var err error
+			if genesisFile == "" {
+				genesis, err = state.DefaultGenesis()
+			} else {
+				genesis, err = state.ReadGenesisFile(state.ExpandPath(genesisFile))
+			}
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			dataDir := getDataDirFromCmd(cmd)
+			if err := state.InitDataDir(dataDir, genesis); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			hash, err := genesis.Hash()
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Printf("Initialised %s for chain '%s'\n", dataDir, genesis.ChainID)
+			fmt.Printf("Genesis hash: %s\n", hash.Hex())
+		},
+	}
+
+	addDefaultRequiredFlags(cmd)
+	cmd.Flags().String(flagGenesis, "", "the genesis file of the chain, e.g. one made with 'mercury genesis new'. The test network genesis by default")
+	return cmd
+}
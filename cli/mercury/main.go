@@ -16,11 +16,33 @@ const (
 	flagPort         = "port"
 	flatRPCHost      = "rpc-host"
 	flagRPCPort      = "rpc-port"
+	flagAdminRPCPort = "admin-rpc-port"
 	flagAddress      = "address"
 	flagName         = "name"
 	flagKeystoreFile = "keystore"
 	flagBootstrap    = "bootstrap"
 	flagTls          = "tls"
+	flagPasswordFile = "password-file"
+	flagDev          = "dev"
+	flagMinerThreads = "miner-threads"
+	flagMine         = "mine"
+	flagEmptyBlocks  = "empty-block-interval"
+	flagRewardSplit  = "reward-split"
+	flagTo           = "to"
+	flagAmount       = "amount"
+	flagFee          = "fee"
+	flagGenesis      = "genesis"
+	flagChainID      = "chain-id"
+	flagAlloc        = "alloc"
+	flagConsensus    = "consensus"
+	flagDifficulty   = "difficulty"
+	flagBlockTime    = "block-time"
+	flagRetarget     = "retarget-interval"
+	flagSigners      = "signers"
+	flagReward       = "reward"
+	flagHalving      = "halving-interval"
+	flagMaxSupply    = "max-supply"
+	flagOut          = "out"
 )
 
 func main() {
@@ -32,8 +54,11 @@ func main() {
 	}
 	// TODO these need to be standardized...
 	mainCmd.AddCommand(versionCmd)
+	mainCmd.AddCommand(initCmd())
+	mainCmd.AddCommand(genesisCmd())
 	mainCmd.AddCommand(runCmd())
 	mainCmd.AddCommand(walletCmd())
+	mainCmd.AddCommand(transferCmd())
 
 	err := mainCmd.Execute()
 	if err != nil {
@@ -3,12 +3,18 @@ package main
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/driemworks/mercury-blockchain/node"
+	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/driemworks/mercury-blockchain/wallet"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/raphamorim/go-rainbow"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -29,6 +35,7 @@ func runCmd() *cobra.Command {
 			port, _ := cmd.Flags().GetUint64(flagPort)
 			rpcHost, _ := cmd.Flags().GetString(flatRPCHost)
 			rpcPort, _ := cmd.Flags().GetUint64(flagRPCPort)
+			adminRPCPort, _ := cmd.Flags().GetUint64(flagAdminRPCPort)
 			bootstrap, _ := cmd.Flags().GetString(flagBootstrap)
 			// validate/fix data issues (thanks Windows)
 			if strings.Contains(bootstrap, "C:/Program Files/Git") {
@@ -43,8 +50,56 @@ func runCmd() *cobra.Command {
 			`)))
 			log.Infoln("Starting mercury")
 			log.Infoln(fmt.Sprintf("Version %s.%s.%s-beta\n", Major, Minor, Patch))
+			dev, _ := cmd.Flags().GetBool(flagDev)
+			if dev {
+				devAccount, created, err := wallet.DevAccount(getDataDirFromCmd(cmd))
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				if err := state.InitDevDataDir(getDataDirFromCmd(cmd), devAccount); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				if address == "" {
+					address = devAccount.Hex()
+				}
+				if created {
+					log.Infof("Running in dev mode with the new pre-funded account %s (empty password)\n", devAccount.Hex())
+				} else {
+					log.Infof("Running in dev mode with the keystore account %s\n", devAccount.Hex())
+				}
+			}
 			n := node.NewNode(name, getDataDirFromCmd(cmd), address, rpcHost, port, false)
-			err := n.Run(context.Background(), host, int(port), rpcHost, rpcPort,
+			n.SetDevMode(dev)
+			n.SetAdminRPCPort(adminRPCPort)
+			if mine, _ := cmd.Flags().GetBool(flagMine); mine || dev {
+				if err := n.StartMining(); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+			}
+			minerThreads, _ := cmd.Flags().GetInt(flagMinerThreads)
+			n.SetMinerThreads(minerThreads)
+			rewardSplit, _ := cmd.Flags().GetString(flagRewardSplit)
+			shares, err := parseRewardSplit(rewardSplit)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			n.SetRewardSplit(shares)
+			emptyBlockInterval, _ := cmd.Flags().GetUint64(flagEmptyBlocks)
+			n.SetEmptyBlockInterval(time.Duration(emptyBlockInterval) * time.Second)
+			passwordFile, _ := cmd.Flags().GetString(flagPasswordFile)
+			if passwordFile != "" {
+				password, err := ioutil.ReadFile(passwordFile)
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				n.SetSealerPassword(strings.TrimSpace(string(password)))
+			}
+			err = n.Run(context.Background(), host, int(port), rpcHost, rpcPort,
 				bootstrap, name)
 			if err != nil {
 				fmt.Println(err)
@@ -56,12 +111,41 @@ func runCmd() *cobra.Command {
 	addDefaultRequiredFlags(runCmd)
 
 	runCmd.Flags().String(flagName, fmt.Sprintf("user-%d", rand.Int()), "Your username")
-	runCmd.Flags().String(flagAddress, "", "miner account of this node to receive block rewards")
+	runCmd.Flags().String(flagAddress, "", "account of this node, it signs the node's txs and receives the rewards of the blocks it mines")
+	runCmd.Flags().Bool(flagMine, false, "mine blocks, requires --address. Without it the node only syncs and serves rpc")
 	runCmd.Flags().Uint64(flagPort, 8080, "The port to run the p2p client on")
 	runCmd.Flags().Uint64(flagRPCPort, 9080, "The port to run the rpc server on")
+	runCmd.Flags().Uint64(flagAdminRPCPort, 9180, "The port of the admin rpc server, which controls the miner. It only listens on 127.0.0.1, 0 to turn it off")
 	runCmd.Flags().String(flagHost, "127.0.0.1", "The host to run the client with")
 	runCmd.Flags().String(flatRPCHost, "0.0.0.0", "The host to run the rpc server on")
 	runCmd.Flags().String(flagBootstrap, "", "the bootstrap server to interconnect peers")
+	runCmd.Flags().String(flagPasswordFile, "", "file containing the password of the miner account, used to seal proof of authority blocks")
+	runCmd.Flags().Bool(flagDev, false, "seal a block as soon as a tx is added, with a generated genesis funding a dev account")
+	runCmd.Flags().Int(flagMinerThreads, 0, "number of goroutines searching for a proof of work nonce, 0 for one per CPU")
+	runCmd.Flags().Uint64(flagEmptyBlocks, 0, "mine an empty block when no block was added for this many seconds, 0 to only mine blocks with txs")
+	runCmd.Flags().String(flagRewardSplit, "", "split block rewards by weight, e.g. '0xabc...:3,0xdef...:1'. All rewards go to --address by default")
 	runCmd.Flags().Bool(flagTls, false, "true if tls is enabled (for the rpc server), false otherwise")
 	return runCmd
 }
+
+/*
+ Parse a comma separated list of address:weight reward shares
+*/
+func parseRewardSplit(value string) ([]state.RewardShare, error) {
+	shares := make([]state.RewardShare, 0)
+	if value == "" {
+		return shares, nil
+	}
+	for _, share := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(share), ":")
+		if len(parts) != 2 || !common.IsHexAddress(parts[0]) {
+			return nil, fmt.Errorf("invalid reward share '%s', expected address:weight", share)
+		}
+		weight, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil || weight == 0 {
+			return nil, fmt.Errorf("invalid reward share weight '%s'", parts[1])
+		}
+		shares = append(shares, state.RewardShare{To: state.NewAddress(parts[0]), Weight: weight})
+	}
+	return shares, state.ValidateRewardShares(shares)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	pb "github.com/driemworks/mercury-blockchain/proto"
+	"github.com/driemworks/mercury-blockchain/state"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+func transferCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "transfer",
+		Short: "Sends tokens from a running node's account to another account.",
+		Run: func(cmd *cobra.Command, args []string) {
+			to, _ := cmd.Flags().GetString(flagTo)
+			if !common.IsHexAddress(to) {
+				fmt.Printf("invalid recipient address '%s'\n", to)
+				os.Exit(1)
+			}
+			amountFlag, _ := cmd.Flags().GetString(flagAmount)
+			amount, err := state.ParseCoins(amountFlag)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			feeFlag, _ := cmd.Flags().GetString(flagFee)
+			fee, err := state.ParseCoins(feeFlag)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			rpcHost, _ := cmd.Flags().GetString(flatRPCHost)
+			rpcPort, _ := cmd.Flags().GetUint64(flagRPCPort)
+			password := getPassPhrase("Please enter the password of the node's account: ", false)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			conn, err := grpc.DialContext(ctx, fmt.Sprintf("%s:%d", rpcHost, rpcPort), grpc.WithInsecure(), grpc.WithBlock())
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			defer conn.Close()
+			response, err := pb.NewNodeServiceClient(conn).Transfer(ctx, &pb.TransferRequest{
+				To:       to,
+				Amount:   amount,
+				Password: password,
+				Fee:      fee,
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Printf("Sent %s coins to %s in tx %s\n", amountFlag, to, response.Hash)
+		},
+	}
+
+	cmd.Flags().String(flagTo, "", "the address to send the tokens to")
+	cmd.MarkFlagRequired(flagTo)
+	cmd.Flags().String(flagAmount, "", "the number of coins to send, e.g. 1.5")
+	cmd.MarkFlagRequired(flagAmount)
+	cmd.Flags().String(flagFee, "0", "the fee in coins paid to the miner, a higher fee gets the transfer mined sooner")
+	cmd.Flags().String(flatRPCHost, "127.0.0.1", "The host of the node's rpc server")
+	cmd.Flags().Uint64(flagRPCPort, 9080, "The port of the node's rpc server")
+	return cmd
+}
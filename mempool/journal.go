@@ -0,0 +1,138 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/sirupsen/logrus"
+)
+
+// journalRecord is a pending tx as it is written to the journal, one json record per line
+type journalRecord struct {
+	TX state.SignedTx `json:"tx"`
+	// when the tx was added to the pool, so it still expires on time after a restart
+	Added time.Time `json:"added"`
+}
+
+// journal keeps the pending txs on disk. Accepted txs are appended and the file is
+// rewritten with only the txs still pending whenever txs leave the pool.
+type journal struct {
+	path string
+	// open for appending once the journal was loaded
+	file *os.File
+}
+
+// at most this many bytes of a corrupt record are logged
+const maxLoggedRecord = 256
+
+/*
+ Call fn with each record in the journal, oldest first. Corrupt records, such as a torn
+ record at the tail of the file from a crash mid-write, are logged and skipped. They stay
+ in the file until the journal is rotated.
+*/
+func (j *journal) load(fn func(journalRecord) error) error {
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	reader := bufio.NewReader(f)
+	for number := 1; ; number++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(line) == 0 {
+			return nil
+		}
+		var record journalRecord
+		if line[len(line)-1] != '\n' || json.Unmarshal(line, &record) != nil {
+			logged := line
+			if len(logged) > maxLoggedRecord {
+				logged = logged[:maxLoggedRecord]
+			}
+			logrus.Warnf("Skipping corrupt record %d of %s: %q\n", number, j.path, logged)
+			continue
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+/*
+ Append a record to the journal
+*/
+func (j *journal) insert(record journalRecord) error {
+	if j.file == nil {
+		return fmt.Errorf("the pending tx journal is not loaded")
+	}
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = j.file.Write(append(recordJSON, '\n'))
+	return err
+}
+
+/*
+ Replace the journal with the given records. They are written to a temporary file that is
+ renamed over the journal, so a crash leaves either the old or the new journal in place.
+*/
+func (j *journal) rotate(records []journalRecord) error {
+	tmpPath := j.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(tmp)
+	for _, record := range records {
+		recordJSON, err := json.Marshal(record)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		writer.Write(append(recordJSON, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if j.file != nil {
+		j.file.Close()
+		j.file = nil
+	}
+	if err := state.Rename(tmpPath, j.path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	j.file = f
+	return nil
+}
+
+func (j *journal) close() error {
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
@@ -0,0 +1,543 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/raphamorim/go-rainbow"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrKnownTx is returned when a tx is already pending or was recently mined
+var ErrKnownTx = errors.New("tx is already known")
+
+// Config bounds the size of the mempool and how long txs stay in it
+type Config struct {
+	// the most txs the mempool holds, the lowest fee txs are evicted to make room
+	MaxSize int
+	// txs that were not mined this long after they were added are dropped
+	MaxAge time.Duration
+	// the percentage by which a tx's fee must exceed the fee of the pending tx
+	// with the same nonce to replace it
+	PriceBump uint64
+	// the file the pending txs are journaled to so they survive a restart, empty to not journal them
+	Journal string
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxSize:   5000,
+		MaxAge:    3 * time.Hour,
+		PriceBump: 10,
+	}
+}
+
+type entry struct {
+	tx    state.SignedTx
+	hash  state.Hash
+	added time.Time
+	// the order the tx was added in
+	seq uint64
+}
+
+// Mempool holds the txs waiting to be mined. Every tx is checked against the committed
+// state and the txs pending before it, so an account's txs have consecutive nonces
+// and the account can pay for all of them.
+type Mempool struct {
+	mu     sync.Mutex
+	config Config
+	chain  *state.State
+	all    map[state.Hash]*entry
+	// the pending txs of each account, by nonce
+	accounts map[common.Address]map[uint]*entry
+	// the hashes of recently mined txs, oldest first, so they aren't added again
+	archived      map[state.Hash]struct{}
+	archivedOrder []state.Hash
+	// the committed state with the pending txs applied
+	pending *state.PendingState
+	journal *journal
+	seq     uint64
+	now     func() time.Time
+}
+
+func New(chain *state.State, config Config) *Mempool {
+	m := &Mempool{
+		config:   config,
+		chain:    chain,
+		all:      make(map[state.Hash]*entry),
+		accounts: make(map[common.Address]map[uint]*entry),
+		archived: make(map[state.Hash]struct{}),
+		pending:  chain.NewPendingState(),
+		now:      time.Now,
+	}
+	if config.Journal != "" {
+		m.journal = &journal{path: config.Journal}
+	}
+	return m
+}
+
+/*
+ Load the txs journaled before the node last stopped. They are checked again against the current
+ state, so the txs that were mined or expired in the meantime, or that are no longer valid, are dropped.
+ Txs accepted from then on are added to the journal.
+*/
+func (m *Mempool) Load() error {
+	if m.journal == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	loaded, dropped := 0, 0
+	err := m.journal.load(func(record journalRecord) error {
+		hash, err := record.TX.Hash()
+		if err != nil {
+			return err
+		}
+		if m.now().Sub(record.Added) > m.config.MaxAge {
+			err = fmt.Errorf("tx expired")
+		} else if record.TX.Nonce <= m.chain.Nonce(record.TX.Author) {
+			err = fmt.Errorf("tx was mined")
+		} else {
+			_, err = m.addTx(record.TX, hash, record.Added)
+		}
+		if err != nil {
+			logrus.Infof("Dropping journaled TX %s: %s\n", rainbow.Yellow(hash.Hex()), err)
+			if record.TX.Nonce > m.chain.Nonce(record.TX.Author) {
+				m.fail(hash, err)
+			}
+			dropped++
+			return nil
+		}
+		loaded++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if loaded > 0 || dropped > 0 {
+		logrus.Infof("Loaded %d pending TX(s) from the journal, dropped %d\n", loaded, dropped)
+	}
+	return m.journal.rotate(m.journalRecords())
+}
+
+/*
+ Close the journal
+*/
+func (m *Mempool) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.journal == nil {
+		return nil
+	}
+	return m.journal.close()
+}
+
+/*
+ Add a tx to the pool. The tx must be signed by its author, have the account's next nonce
+ and be affordable after the account's other pending txs. A tx with the nonce of a pending
+ tx replaces it if it pays a high enough fee. When the pool is full the tx evicts the
+ lowest fee tx, if it pays more.
+*/
+func (m *Mempool) Add(tx state.SignedTx) error {
+	hash, err := tx.Hash()
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	removed, err := m.addTx(tx, hash, m.now())
+	if err != nil {
+		return err
+	}
+	if removed {
+		m.rotateJournal()
+	} else {
+		m.journalTx(m.all[hash])
+	}
+	return nil
+}
+
+/*
+ Add the tx if it passes the checks of Add. True if other txs were replaced or evicted to make room for it.
+*/
+func (m *Mempool) addTx(tx state.SignedTx, hash state.Hash, added time.Time) (bool, error) {
+	if _, ok := m.all[hash]; ok {
+		return false, ErrKnownTx
+	}
+	if _, ok := m.archived[hash]; ok {
+		return false, ErrKnownTx
+	}
+	if err := validate(tx, m.chain.ChainID()); err != nil {
+		return false, err
+	}
+	if committed := m.chain.Nonce(tx.Author); tx.Nonce <= committed {
+		return false, fmt.Errorf("nonce '%d' was already used, the next nonce of '%s' is '%d'",
+			tx.Nonce, tx.Author.Hex(), committed+1)
+	}
+	if old, ok := m.accounts[tx.Author][tx.Nonce]; ok {
+		if err := m.replace(old, tx, hash, added); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if next := m.pending.Nonce(tx.Author) + 1; tx.Nonce != next {
+		return false, fmt.Errorf("nonce must be '%d' not '%d'", next, tx.Nonce)
+	}
+	if balance := m.pending.Balance(tx.Author); balance < tx.Cost() {
+		return false, fmt.Errorf("insufficient balance. '%s' has %d pending but the tx costs %d",
+			tx.Author.Hex(), balance, tx.Cost())
+	}
+	evicted := false
+	if len(m.all) >= m.config.MaxSize {
+		if err := m.evictFor(tx); err != nil {
+			return false, err
+		}
+		evicted = true
+	}
+	if err := m.pending.ApplyTx(tx); err != nil {
+		return evicted, err
+	}
+	m.insert(tx, hash, added)
+	return evicted, nil
+}
+
+/*
+ The pending txs, in the order they were added except that each account's txs are in nonce order
+*/
+func (m *Mempool) Pending() []state.SignedTx {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := m.ordered()
+	txs := make([]state.SignedTx, len(entries))
+	for i, e := range entries {
+		txs[i] = e.tx
+	}
+	return txs
+}
+
+func (m *Mempool) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.all)
+}
+
+/*
+ The pending tx with the given hash
+*/
+func (m *Mempool) Get(hash state.Hash) (state.SignedTx, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.all[hash]
+	if !ok {
+		return state.SignedTx{}, false
+	}
+	return e.tx, true
+}
+
+/*
+ The nonce of the account's latest tx, pending or mined
+*/
+func (m *Mempool) Nonce(account common.Address) uint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pending.Nonce(account)
+}
+
+/*
+ The balance of the account once its pending txs are mined
+*/
+func (m *Mempool) Balance(account common.Address) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pending.Balance(account)
+}
+
+/*
+ Update the pool after the canonical chain changed: txs of added blocks are removed,
+ txs of removed blocks are pending again, and txs the new state no longer allows are dropped
+*/
+func (m *Mempool) Update(update state.ChainUpdate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, block := range update.Added {
+		for _, tx := range block.TXs {
+			hash, err := tx.Hash()
+			if err != nil {
+				continue
+			}
+			if e, ok := m.all[hash]; ok {
+				logrus.Infof("Archiving mined TX: %s\n", rainbow.Yellow(hash.Hex()))
+				m.remove(e)
+			}
+			m.archive(hash)
+		}
+	}
+	for _, tx := range update.OrphanedTXs() {
+		hash, err := tx.Hash()
+		if err != nil {
+			continue
+		}
+		if _, ok := m.all[hash]; ok {
+			continue
+		}
+		logrus.Infof("Restoring orphaned TX: %s\n", rainbow.Yellow(hash.Hex()))
+		delete(m.archived, hash)
+		m.insert(tx, hash, m.now())
+	}
+	m.reset()
+	m.rotateJournal()
+}
+
+/*
+ Drop a pending tx that turned out to be invalid for 'reason', and the txs of the same account after it
+*/
+func (m *Mempool) Remove(hash state.Hash, reason error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.all[hash]
+	if !ok {
+		return
+	}
+	m.remove(e)
+	m.fail(hash, reason)
+	m.reset()
+	m.rotateJournal()
+}
+
+/*
+ Drop the txs that were not mined within the max age, and the txs of the same accounts after them
+*/
+func (m *Mempool) RemoveExpired() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expired := false
+	for hash, e := range m.all {
+		if m.now().Sub(e.added) > m.config.MaxAge {
+			logrus.Infof("Dropping expired TX: %s\n", rainbow.Yellow(hash.Hex()))
+			m.remove(e)
+			m.fail(hash, fmt.Errorf("tx was not mined within %s", m.config.MaxAge))
+			expired = true
+		}
+	}
+	if expired {
+		m.reset()
+		m.rotateJournal()
+	}
+}
+
+/*
+ Replace the pending tx 'old' with 'tx', which has the same author and nonce
+*/
+func (m *Mempool) replace(old *entry, tx state.SignedTx, hash state.Hash, added time.Time) error {
+	minFee := old.tx.Fee + old.tx.Fee*m.config.PriceBump/100
+	if tx.Fee <= old.tx.Fee || tx.Fee < minFee {
+		return fmt.Errorf("a tx replacing nonce '%d' must pay a fee of at least %d, more than %d",
+			tx.Nonce, minFee, old.tx.Fee)
+	}
+	if balance := m.pending.Balance(tx.Author) + old.tx.Cost(); balance < tx.Cost() {
+		return fmt.Errorf("insufficient balance. '%s' has %d pending but the tx costs %d",
+			tx.Author.Hex(), balance, tx.Cost())
+	}
+	logrus.Infof("Replacing TX %s with %s\n", rainbow.Yellow(old.hash.Hex()), rainbow.Yellow(hash.Hex()))
+	m.remove(old)
+	m.fail(old.hash, fmt.Errorf("tx was replaced by %s", hash.Hex()))
+	// the replacement takes the place of the tx it replaces
+	m.add(&entry{tx, hash, added, old.seq})
+	m.reset()
+	return nil
+}
+
+/*
+ Make room for 'tx' by evicting the lowest fee tx that is the last pending tx of its account
+*/
+func (m *Mempool) evictFor(tx state.SignedTx) error {
+	var lowest *entry
+	for account, txs := range m.accounts {
+		// evicting one of the author's own txs would leave a nonce gap before 'tx'
+		if account == tx.Author {
+			continue
+		}
+		var last *entry
+		for _, e := range txs {
+			if last == nil || e.tx.Nonce > last.tx.Nonce {
+				last = e
+			}
+		}
+		if last != nil && (lowest == nil || last.tx.Fee < lowest.tx.Fee ||
+			(last.tx.Fee == lowest.tx.Fee && last.seq > lowest.seq)) {
+			lowest = last
+		}
+	}
+	if lowest == nil || tx.Fee <= lowest.tx.Fee {
+		return fmt.Errorf("mempool is full, the fee must be more than the lowest pending fee")
+	}
+	logrus.Infof("Mempool is full, evicting TX: %s\n", rainbow.Yellow(lowest.hash.Hex()))
+	m.remove(lowest)
+	m.fail(lowest.hash, fmt.Errorf("tx was evicted from the full mempool by a tx paying a higher fee"))
+	m.reset()
+	return nil
+}
+
+func (m *Mempool) insert(tx state.SignedTx, hash state.Hash, added time.Time) {
+	m.add(&entry{tx, hash, added, m.seq})
+	m.seq++
+}
+
+func (m *Mempool) add(e *entry) {
+	m.all[e.hash] = e
+	if m.accounts[e.tx.Author] == nil {
+		m.accounts[e.tx.Author] = make(map[uint]*entry)
+	}
+	m.accounts[e.tx.Author][e.tx.Nonce] = e
+}
+
+/*
+ Record the receipt of a tx that left the pool without being mined
+*/
+func (m *Mempool) fail(hash state.Hash, reason error) {
+	if err := m.chain.AddFailedReceipt(hash, reason); err != nil {
+		logrus.Warnf("Failed to write the receipt of TX %s: %s\n", rainbow.Yellow(hash.Hex()), err)
+	}
+}
+
+func (m *Mempool) remove(e *entry) {
+	delete(m.all, e.hash)
+	delete(m.accounts[e.tx.Author], e.tx.Nonce)
+	if len(m.accounts[e.tx.Author]) == 0 {
+		delete(m.accounts, e.tx.Author)
+	}
+}
+
+/*
+ Remember a mined tx, forgetting the oldest once there are more than the pool holds
+*/
+func (m *Mempool) archive(hash state.Hash) {
+	if _, ok := m.archived[hash]; ok {
+		return
+	}
+	m.archived[hash] = struct{}{}
+	m.archivedOrder = append(m.archivedOrder, hash)
+	for len(m.archivedOrder) > m.config.MaxSize {
+		delete(m.archived, m.archivedOrder[0])
+		m.archivedOrder = m.archivedOrder[1:]
+	}
+}
+
+/*
+ Rebuild the pending state on top of the committed state, dropping the txs that are
+ already mined, follow a nonce gap or can no longer be paid for
+*/
+func (m *Mempool) reset() {
+	pending := m.chain.NewPendingState()
+	for _, e := range m.ordered() {
+		var err error
+		if e.tx.Nonce <= m.chain.Nonce(e.tx.Author) {
+			err = fmt.Errorf("nonce '%d' was already used", e.tx.Nonce)
+		} else if next := pending.Nonce(e.tx.Author) + 1; e.tx.Nonce != next {
+			err = fmt.Errorf("nonce must be '%d' not '%d'", next, e.tx.Nonce)
+		} else {
+			err = pending.ApplyTx(e.tx)
+		}
+		if err != nil {
+			logrus.Warnf("Dropping pending TX %s: %s\n", rainbow.Yellow(e.hash.Hex()), err)
+			m.remove(e)
+			m.fail(e.hash, err)
+		}
+	}
+	m.pending = pending
+}
+
+/*
+ The entries in the order they were added, except that each account's entries are in nonce order
+*/
+func (m *Mempool) ordered() []*entry {
+	entries := make([]*entry, 0, len(m.all))
+	for _, e := range m.all {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].seq < entries[j].seq
+	})
+	byAccount := make(map[common.Address][]*entry)
+	for _, e := range entries {
+		byAccount[e.tx.Author] = append(byAccount[e.tx.Author], e)
+	}
+	for _, accountEntries := range byAccount {
+		sort.Slice(accountEntries, func(i, j int) bool {
+			return accountEntries[i].tx.Nonce < accountEntries[j].tx.Nonce
+		})
+	}
+	// each account keeps the slots its entries were added in, filled in nonce order
+	ordered := make([]*entry, 0, len(entries))
+	next := make(map[common.Address]int)
+	for _, e := range entries {
+		ordered = append(ordered, byAccount[e.tx.Author][next[e.tx.Author]])
+		next[e.tx.Author]++
+	}
+	return ordered
+}
+
+/*
+ Append a newly added tx to the journal
+*/
+func (m *Mempool) journalTx(e *entry) {
+	if !m.journaling() {
+		return
+	}
+	if err := m.journal.insert(journalRecord{e.tx, e.added}); err != nil {
+		logrus.Warnf("Failed to journal pending TX %s: %s\n", rainbow.Yellow(e.hash.Hex()), err)
+	}
+}
+
+/*
+ Rewrite the journal with the txs that are pending now, after txs were removed from the pool
+*/
+func (m *Mempool) rotateJournal() {
+	if !m.journaling() {
+		return
+	}
+	if err := m.journal.rotate(m.journalRecords()); err != nil {
+		logrus.Warnf("Failed to rotate the pending TX journal: %s\n", err)
+	}
+}
+
+/*
+ True once the journal was loaded, the journal is only written after that so it can't be overwritten before it was read
+*/
+func (m *Mempool) journaling() bool {
+	return m.journal != nil && m.journal.file != nil
+}
+
+func (m *Mempool) journalRecords() []journalRecord {
+	entries := m.ordered()
+	records := make([]journalRecord, len(entries))
+	for i, e := range entries {
+		records[i] = journalRecord{e.tx, e.added}
+	}
+	return records
+}
+
+/*
+ Check the parts of the tx that don't depend on the state: its payload and signature
+*/
+func validate(tx state.SignedTx, chainID string) error {
+	if err := tx.ValidatePayload(); err != nil {
+		return err
+	}
+	if tx.TxType() == state.TxTypeCoinbase {
+		return fmt.Errorf("coinbase txs are only created by miners")
+	}
+	ok, err := tx.IsAuthentic(chainID)
+	if err != nil {
+		return fmt.Errorf("invalid tx signature. %s", err.Error())
+	}
+	if !ok {
+		return fmt.Errorf("tx is not signed by its author '%s'", tx.Author.Hex())
+	}
+	return nil
+}
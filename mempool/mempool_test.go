@@ -0,0 +1,217 @@
+package mempool
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/driemworks/mercury-blockchain/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the test state has no genesis, and so no chain ID
+const testChainID = ""
+
+type testAccount struct {
+	address common.Address
+	key     *ecdsa.PrivateKey
+}
+
+func newTestAccount(t *testing.T) testAccount {
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+	return testAccount{crypto.PubkeyToAddress(key.PublicKey), key}
+}
+
+func (a testAccount) transfer(t *testing.T, amount uint64, fee uint64, nonce uint) state.SignedTx {
+	to := state.NewAddress("0x0000000000000000000000000000000000000001")
+	tx, err := wallet.SignTx(state.NewTransferTx(a.address, to, amount, nonce).WithFee(fee), testChainID, a.key)
+	assert.Nil(t, err)
+	return tx
+}
+
+func newTestPool(config Config, accounts ...testAccount) (*Mempool, *state.State) {
+	s := state.NewMemoryState(make(map[common.Address]state.CurrentNodeState), make(map[common.Address]uint))
+	for _, account := range accounts {
+		s.Catalog[account.address] = state.CurrentNodeState{Balance: 100}
+	}
+	return New(s, config), s
+}
+
+func TestAdd(t *testing.T) {
+	a := newTestAccount(t)
+	pool, s := newTestPool(DefaultConfig(), a)
+	s.Account2Nonce[a.address] = 1
+
+	// nonces must follow the committed nonce without gaps
+	assert.NotNil(t, pool.Add(a.transfer(t, 10, 0, 1)))
+	assert.NotNil(t, pool.Add(a.transfer(t, 10, 0, 3)))
+	tx := a.transfer(t, 10, 0, 2)
+	assert.Nil(t, pool.Add(tx))
+	assert.Equal(t, ErrKnownTx, pool.Add(tx))
+	assert.Equal(t, uint(2), pool.Nonce(a.address))
+	assert.Equal(t, uint64(90), pool.Balance(a.address))
+
+	// the account can't spend more than it has after its pending txs
+	assert.NotNil(t, pool.Add(a.transfer(t, 91, 0, 3)))
+	assert.Nil(t, pool.Add(a.transfer(t, 80, 10, 3)))
+
+	// unsigned and forged txs are rejected
+	other := newTestAccount(t)
+	assert.NotNil(t, pool.Add(state.NewSignedTx(state.NewTransferTx(a.address, other.address, 1, 4), nil)))
+	forged, err := wallet.SignTx(state.NewTransferTx(a.address, other.address, 1, 4), testChainID, other.key)
+	assert.Nil(t, err)
+	assert.NotNil(t, pool.Add(forged))
+	assert.Equal(t, 2, pool.Len())
+}
+
+func TestAdd_ReplaceByFee(t *testing.T) {
+	a := newTestAccount(t)
+	pool, _ := newTestPool(DefaultConfig(), a)
+	first := a.transfer(t, 10, 10, 1)
+	assert.Nil(t, pool.Add(first))
+	assert.Nil(t, pool.Add(a.transfer(t, 10, 10, 2)))
+
+	// the fee must rise by the price bump
+	assert.NotNil(t, pool.Add(a.transfer(t, 20, 10, 1)))
+	replacement := a.transfer(t, 10, 11, 1)
+	assert.Nil(t, pool.Add(replacement))
+	_, ok := pool.Get(mustHash(t, first))
+	assert.False(t, ok)
+	assert.Equal(t, replacement, pool.Pending()[0])
+	assert.Equal(t, 2, pool.Len())
+
+	// a replacement that can't be paid for drops nothing
+	assert.NotNil(t, pool.Add(a.transfer(t, 70, 20, 1)))
+	assert.Equal(t, 2, pool.Len())
+}
+
+func TestAdd_EvictsLowestFee(t *testing.T) {
+	a := newTestAccount(t)
+	b := newTestAccount(t)
+	config := DefaultConfig()
+	config.MaxSize = 2
+	pool, _ := newTestPool(config, a, b)
+	assert.Nil(t, pool.Add(a.transfer(t, 1, 1, 1)))
+	cheap := a.transfer(t, 1, 2, 2)
+	assert.Nil(t, pool.Add(cheap))
+
+	// the new tx must outbid the last tx of another account
+	assert.NotNil(t, pool.Add(b.transfer(t, 1, 2, 1)))
+	assert.Nil(t, pool.Add(b.transfer(t, 1, 3, 1)))
+	_, ok := pool.Get(mustHash(t, cheap))
+	assert.False(t, ok)
+	assert.Equal(t, 2, pool.Len())
+}
+
+func TestUpdate(t *testing.T) {
+	a := newTestAccount(t)
+	pool, s := newTestPool(DefaultConfig(), a)
+	mined := a.transfer(t, 10, 0, 1)
+	next := a.transfer(t, 10, 0, 2)
+	assert.Nil(t, pool.Add(mined))
+	assert.Nil(t, pool.Add(next))
+
+	// the mined tx is archived and can't be added again
+	s.Account2Nonce[a.address] = 1
+	s.Catalog[a.address] = state.CurrentNodeState{Balance: 90}
+	block := state.Block{TXs: []state.SignedTx{mined}}
+	pool.Update(state.ChainUpdate{Added: []state.Block{block}})
+	assert.Equal(t, []state.SignedTx{next}, pool.Pending())
+	assert.Equal(t, ErrKnownTx, pool.Add(mined))
+
+	// a reorg that drops the block makes its txs pending again
+	s.Account2Nonce[a.address] = 0
+	s.Catalog[a.address] = state.CurrentNodeState{Balance: 100}
+	pool.Update(state.ChainUpdate{Removed: []state.Block{block}})
+	assert.Equal(t, []state.SignedTx{mined, next}, pool.Pending())
+}
+
+func TestRemoveExpired(t *testing.T) {
+	a := newTestAccount(t)
+	b := newTestAccount(t)
+	pool, _ := newTestPool(DefaultConfig(), a, b)
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+	assert.Nil(t, pool.Add(a.transfer(t, 1, 0, 1)))
+	now = now.Add(time.Hour)
+	assert.Nil(t, pool.Add(a.transfer(t, 1, 0, 2)))
+	fresh := b.transfer(t, 1, 0, 1)
+	assert.Nil(t, pool.Add(fresh))
+
+	// the txs after an expired tx of the same account are dropped with it
+	now = now.Add(DefaultConfig().MaxAge)
+	pool.RemoveExpired()
+	assert.Equal(t, []state.SignedTx{fresh}, pool.Pending())
+	assert.Equal(t, uint64(100), pool.Balance(a.address))
+}
+
+func TestJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mempool_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	a := newTestAccount(t)
+	b := newTestAccount(t)
+	config := DefaultConfig()
+	config.Journal = filepath.Join(dir, "mempool.journal")
+	pool, s := newTestPool(config, a, b)
+	assert.Nil(t, pool.Load())
+	mined := a.transfer(t, 10, 0, 1)
+	next := a.transfer(t, 10, 0, 2)
+	other := b.transfer(t, 10, 0, 1)
+	for _, tx := range []state.SignedTx{mined, next, other} {
+		assert.Nil(t, pool.Add(tx))
+	}
+	// replacing a tx rewrites the journal without it
+	replacement := a.transfer(t, 10, 1, 2)
+	assert.Nil(t, pool.Add(replacement))
+	assert.Nil(t, pool.Close())
+
+	// the tx mined while the node was stopped is dropped when the journal is loaded
+	s.Account2Nonce[a.address] = 1
+	s.Catalog[a.address] = state.CurrentNodeState{Balance: 90}
+	restarted := New(s, config)
+	assert.Nil(t, restarted.Load())
+	assert.Equal(t, []state.SignedTx{replacement, other}, restarted.Pending())
+	assert.Equal(t, uint64(79), restarted.Balance(a.address))
+
+	// a record torn by a crash is skipped
+	assert.Nil(t, restarted.Close())
+	f, err := os.OpenFile(config.Journal, os.O_WRONLY|os.O_APPEND, 0600)
+	assert.Nil(t, err)
+	_, err = f.Write([]byte(`{"tx":{"author"`))
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+	restarted = New(s, config)
+	assert.Nil(t, restarted.Load())
+	assert.Equal(t, 2, restarted.Len())
+	assert.Nil(t, restarted.Close())
+
+	// so is a corrupt record between valid ones, and the journal is rewritten without it
+	content, err := ioutil.ReadFile(config.Journal)
+	assert.Nil(t, err)
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	corrupted := append(append(append([]byte{}, lines[0]...), []byte("{not json\n")...), lines[1]...)
+	assert.Nil(t, ioutil.WriteFile(config.Journal, corrupted, 0600))
+	restarted = New(s, config)
+	assert.Nil(t, restarted.Load())
+	assert.Equal(t, []state.SignedTx{replacement, other}, restarted.Pending())
+	assert.Nil(t, restarted.Close())
+	content, err = ioutil.ReadFile(config.Journal)
+	assert.Nil(t, err)
+	assert.False(t, bytes.Contains(content, []byte("not json")))
+}
+
+func mustHash(t *testing.T, tx state.SignedTx) state.Hash {
+	hash, err := tx.Hash()
+	assert.Nil(t, err)
+	return hash
+}
@@ -0,0 +1,49 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/driemworks/mercury-blockchain/proto"
+	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type adminServer struct {
+	pb.UnimplementedAdminServiceServer
+	node *Node
+}
+
+/*
+	Admin: start mining with the node's coinbase
+*/
+func (server adminServer) StartMining(
+	ctx context.Context, request *pb.StartMiningRequest) (*pb.MiningStatusResponse, error) {
+	if err := server.node.StartMining(); err != nil {
+		return nil, err
+	}
+	return miningStatus(server.node), nil
+}
+
+/*
+	Admin: stop mining
+*/
+func (server adminServer) StopMining(
+	ctx context.Context, request *pb.StopMiningRequest) (*pb.MiningStatusResponse, error) {
+	server.node.StopMining()
+	return miningStatus(server.node), nil
+}
+
+/*
+	Admin: change the address that receives block rewards
+*/
+func (server adminServer) SetCoinbase(
+	ctx context.Context, request *pb.SetCoinbaseRequest) (*pb.MiningStatusResponse, error) {
+	if !common.IsHexAddress(request.Address) {
+		return nil, fmt.Errorf("invalid coinbase address '%s'", request.Address)
+	}
+	if err := server.node.SetCoinbase(state.NewAddress(request.Address)); err != nil {
+		return nil, err
+	}
+	return miningStatus(server.node), nil
+}
@@ -0,0 +1,100 @@
+package node
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// the most pending txs a mined block includes, besides its coinbase
+	maxBlockTXs = 500
+	// the number of recent blocks whose fees the fee estimate is based on
+	feeHistoryBlocks = 20
+)
+
+/*
+	Pick up to 'limit' txs, highest fee first. An account's txs are picked in nonce order,
+	so a high fee tx waits for the account's txs before it.
+*/
+func selectTXsByFee(txs []state.SignedTx, limit int) []state.SignedTx {
+	byAccount := make(map[common.Address][]state.SignedTx)
+	for _, tx := range txs {
+		byAccount[tx.Author] = append(byAccount[tx.Author], tx)
+	}
+	accounts := make([]common.Address, 0, len(byAccount))
+	for account, accountTXs := range byAccount {
+		sort.SliceStable(accountTXs, func(i, j int) bool {
+			return accountTXs[i].Nonce < accountTXs[j].Nonce
+		})
+		accounts = append(accounts, account)
+	}
+	// ties go to the lower address so every node builds the same block from the same txs
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i][:], accounts[j][:]) < 0
+	})
+
+	selected := make([]state.SignedTx, 0)
+	for len(selected) < limit {
+		best := -1
+		for i, account := range accounts {
+			if len(byAccount[account]) == 0 {
+				continue
+			}
+			if best == -1 || byAccount[account][0].Fee > byAccount[accounts[best]][0].Fee {
+				best = i
+			}
+		}
+		if best == -1 {
+			break
+		}
+		account := accounts[best]
+		selected = append(selected, byAccount[account][0])
+		byAccount[account] = byAccount[account][1:]
+	}
+	return selected
+}
+
+/*
+	Estimate a fee that gets a tx into one of the next blocks: the median fee of the txs
+	in recent blocks, raised to outbid the pending txs if there are more than fit in a block
+*/
+func (n *Node) EstimateFee() (uint64, error) {
+	fees := make([]uint64, 0)
+	hash := n.state.LatestBlockHash()
+	for i := 0; i < feeHistoryBlocks && !hash.IsEmpty(); i++ {
+		block, ok, err := n.state.GetBlock(hash)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		for _, tx := range block.TXs {
+			if tx.TxType() != state.TxTypeCoinbase {
+				fees = append(fees, tx.Fee)
+			}
+		}
+		hash = block.Header.Parent
+	}
+	estimate := uint64(0)
+	if len(fees) > 0 {
+		sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+		estimate = fees[len(fees)/2]
+	}
+
+	pendingTXs := n.mempool.Pending()
+	pendingFees := make([]uint64, 0, len(pendingTXs))
+	for _, tx := range pendingTXs {
+		pendingFees = append(pendingFees, tx.Fee)
+	}
+	if len(pendingFees) >= maxBlockTXs {
+		sort.Slice(pendingFees, func(i, j int) bool { return pendingFees[i] > pendingFees[j] })
+		if cutoff := pendingFees[maxBlockTXs-1] + 1; cutoff > estimate {
+			estimate = cutoff
+		}
+	}
+	return estimate, nil
+}
@@ -0,0 +1,45 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/driemworks/mercury-blockchain/wallet"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectTXsByFee(t *testing.T) {
+	a := state.NewAddress("0x0000000000000000000000000000000000000001")
+	b := state.NewAddress("0x0000000000000000000000000000000000000002")
+	a1 := state.NewSignedTx(state.NewTx(a, "a1", 1).WithFee(1), nil)
+	a2 := state.NewSignedTx(state.NewTx(a, "a2", 2).WithFee(10), nil)
+	b1 := state.NewSignedTx(state.NewTx(b, "b1", 1).WithFee(5), nil)
+
+	// a2 pays the most but waits for a1, which pays less than b1
+	assert.Equal(t, []state.SignedTx{b1, a1, a2}, selectTXsByFee([]state.SignedTx{a2, b1, a1}, 10))
+	assert.Equal(t, []state.SignedTx{b1, a1}, selectTXsByFee([]state.SignedTx{a2, b1, a1}, 2))
+	assert.Empty(t, selectTXsByFee(nil, 10))
+}
+
+func TestEstimateFee(t *testing.T) {
+	n, key, cleanup := newTestWorkNodeWithKey(t)
+	defer cleanup()
+	author := n.Coinbase()
+
+	// no fees were paid yet
+	fee, err := n.EstimateFee()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), fee)
+
+	// a full pool must be outbid
+	signer := state.NewAddress("0x0000000000000000000000000000000000000001")
+	for nonce := uint(2); nonce < maxBlockTXs+2; nonce++ {
+		tx, err := wallet.SignTx(state.NewVoteTx(author, signer, true, nonce).WithFee(7), n.state.ChainID(), key)
+		assert.Nil(t, err)
+		assert.Nil(t, n.AddPendingTX(tx))
+	}
+	fee, err = n.EstimateFee()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(8), fee)
+}
@@ -4,10 +4,10 @@ import (
 	"bufio"
 	"context"
 	"crypto/rand"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/driemworks/mercury-blockchain/core"
@@ -66,7 +66,7 @@ func makeHost(ip string, port int, insecure bool) (host.Host, error) {
 	Manually add a peer to the DHT
 	If doRelay = true then open a connection with the peer
 */
-func addPeers(ctx context.Context, n Node, peersArg string, doRelay bool) {
+func addPeers(ctx context.Context, n *Node, peersArg string, doRelay bool) {
 	if len(peersArg) == 0 {
 		return
 	}
@@ -105,7 +105,7 @@ func (n *Node) runLibp2pNode(ctx context.Context, ip string, port int, bootstrap
 		// TODO leaving as localhost for now. Should this be configurable?
 		bootstrapPeer = fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", ip, port, host.ID().Pretty())
 	} else {
-		addPeers(ctx, *n, bootstrapPeer, true)
+		addPeers(ctx, n, bootstrapPeer, true)
 	}
 
 	logrus.Infoln("Listening on", host.Addrs())
@@ -116,17 +116,27 @@ func (n *Node) runLibp2pNode(ctx context.Context, ip string, port int, bootstrap
 		buf := bufio.NewReader(s)
 		bytes, err := buf.ReadBytes('\n')
 		// decode bytes
-		var decoded [32]byte
-		hex.Decode(bytes[:], decoded[:])
-		blocks, err := state.GetBlocksAfter(decoded, n.datadir)
+		var decoded state.Hash
+		err = decoded.UnmarshalText([]byte(strings.TrimSpace(string(bytes))))
+		if err != nil {
+			logrus.Errorln("failed to decode the peer's latest block hash: ", err)
+			decoded = state.Hash{}
+		}
+		blocks, err := n.state.GetBlocksAfter(decoded)
 		if err != nil {
 			log.Fatalln(err)
 		}
 		if blocks != nil {
 			streamData(ctx, host, DiscoveryServiceTag_Blocks, s.Conn().RemotePeer(), blocks)
 		}
-		if n.pendingTXs != nil {
-			streamData(ctx, host, DiscoveryServiceTag_PendingTxs, s.Conn().RemotePeer(), n.pendingTXs)
+		if pendingTXs := n.mempool.Pending(); len(pendingTXs) > 0 {
+			// pending txs are sent keyed by their hash
+			txs := make(map[string]state.SignedTx, len(pendingTXs))
+			for _, tx := range pendingTXs {
+				txHash, _ := tx.Hash()
+				txs[txHash.Hex()] = tx
+			}
+			streamData(ctx, host, DiscoveryServiceTag_PendingTxs, s.Conn().RemotePeer(), txs)
 		}
 		err = s.Close()
 		if err != nil {
@@ -146,7 +156,19 @@ func (n *Node) runLibp2pNode(ctx context.Context, ip string, port int, bootstrap
 		if err != nil {
 			log.Fatalln(err)
 		}
-		n.pendingTXs = txs
+		// add each account's txs in nonce order, so none of them follows a nonce gap
+		sorted := make([]state.SignedTx, 0, len(txs))
+		for _, tx := range txs {
+			sorted = append(sorted, tx)
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].Nonce < sorted[j].Nonce
+		})
+		for _, tx := range sorted {
+			if err := n.AddPendingTX(tx); err != nil {
+				logrus.Warnln(err)
+			}
+		}
 		err = s.Close()
 		if err != nil {
 			log.Fatalln(err)
@@ -166,7 +188,7 @@ func (n *Node) runLibp2pNode(ctx context.Context, ip string, port int, bootstrap
 			log.Fatalln(err)
 		}
 		for _, b := range blocks {
-			_, _, err = n.state.AddBlock(b)
+			_, err = n.addBlock(b)
 			if err != nil {
 				log.Fatalln(err)
 			}
@@ -195,7 +217,9 @@ func (n *Node) runLibp2pNode(ctx context.Context, ip string, port int, bootstrap
 		if err != nil {
 			logrus.Errorln("failed to unmarshal json to SignedTx: ", err)
 		}
-		n.AddPendingTX(tx)
+		if err := n.AddPendingTX(tx); err != nil {
+			logrus.Warnln(err)
+		}
 	}, n.newPendingTXs)
 	// join the reserved block sync topic
 	go n.Join(ctx, core.NEW_BLOCKS_TOPIC, 128, func(data *pubsub.Message) {
@@ -204,12 +228,17 @@ func (n *Node) runLibp2pNode(ctx context.Context, ip string, port int, bootstrap
 		if err != nil {
 			log.Fatalln("failed to unmarshal json to Block: ", err)
 		}
-		s, _, err := n.state.AddBlock(b)
+		update, err := n.addBlock(b)
 		if err != nil {
-			if s != nil {
-				n.state = s
-			}
 			logrus.Errorln("failed to add block: ", err)
+			return
+		}
+		if len(update.Added) > 0 {
+			// stop mining on top of the old chain tip
+			select {
+			case n.newSyncedBlocks <- b:
+			default:
+			}
 		}
 	}, n.newMinedBlocks)
 	select {}
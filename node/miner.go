@@ -3,7 +3,6 @@ package node
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"time"
 
 	"github.com/driemworks/mercury-blockchain/state"
@@ -15,67 +14,67 @@ import (
 
 // PendingBlock represents a block before it has been mined
 type PendingBlock struct {
-	parent state.Hash
-	number uint64
-	time   uint64
-	miner  common.Address
-	txs    []state.SignedTx
+	parent    state.Hash
+	stateRoot state.Hash
+	number    uint64
+	time      uint64
+	miner     common.Address
+	txs       []state.SignedTx
+	// the candidate txs left out of the block because they failed to apply
+	dropped []state.DroppedTx
 }
 
-func NewPendingBlock(parent state.Hash, number uint64, miner common.Address, txs []state.SignedTx) PendingBlock {
-	return PendingBlock{parent, number, uint64(time.Now().Unix()), miner, txs}
+func NewPendingBlock(parent state.Hash, stateRoot state.Hash, number uint64, miner common.Address, txs []state.SignedTx) PendingBlock {
+	return PendingBlock{parent, stateRoot, number, uint64(time.Now().Unix()), miner, txs, nil}
 }
 
-func generateNonce() uint32 {
-	rand.Seed(time.Now().UTC().UnixNano())
-	return rand.Uint32()
-}
+/*
+	Build the block for the pending block with its header prepared by the consensus engine, ready to be sealed
+*/
+func PrepareBlock(engine state.Engine, chain state.ChainReader, pb PendingBlock) (state.Block, error) {
+	txRoot, err := state.TxRoot(pb.txs)
+	if err != nil {
+		return state.Block{}, fmt.Errorf("couldn't mine block. %s", err.Error())
+	}
 
-func Mine(ctx context.Context, pb PendingBlock) (state.Block, error) {
-	if len(pb.txs) == 0 {
-		return state.Block{}, fmt.Errorf(rainbow.Red("block is empty - there is nothing to mine"))
+	block := state.NewBlock(pb.parent, pb.stateRoot, txRoot, pb.time, pb.number, 0, pb.txs, 0, pb.miner, 0)
+	if err := engine.Prepare(chain, &block.Header); err != nil {
+		return state.Block{}, fmt.Errorf("couldn't mine block. %s", err.Error())
 	}
+	return block, nil
+}
 
+/*
+	Mine the pending block: the consensus engine prepares the block's header and seals it
+*/
+func Mine(ctx context.Context, engine state.Engine, chain state.ChainReader, pb PendingBlock) (state.Block, error) {
 	start := time.Now()
-	attempt := 0
-	var block state.Block
-	var hash state.Hash
-	var nonce uint32
-
-	for !state.IsBlockHashValid(hash) {
-		select {
-		case <-ctx.Done():
-			logrus.Infoln("Mining cancelled!")
-			return state.Block{}, fmt.Errorf(rainbow.Red("mining cancelled. %s"), ctx.Err())
-		default:
-		}
-
-		attempt++
-		nonce = generateNonce()
-
-		if attempt%1000000 == 0 || attempt == 1 {
-			logrus.Infoln("Mining " + rainbow.Magenta(fmt.Sprintf("%d", len(pb.txs))) + " Pending TXs. Attempt: " + rainbow.Magenta(fmt.Sprintf("%d", attempt)))
-		}
-
-		block = state.NewBlock(pb.parent, pb.time, pb.number, pb.txs, nonce, pb.miner, attempt)
-		blockHash, err := block.Hash()
-		if err != nil {
-			return state.Block{}, fmt.Errorf("couldn't mine block. %s", err.Error())
-		}
-
-		hash = blockHash
+	block, err := PrepareBlock(engine, chain, pb)
+	if err != nil {
+		return state.Block{}, err
+	}
+	block, err = engine.Seal(ctx, block)
+	if err != nil {
+		return state.Block{}, err
 	}
+	hash, err := block.Hash()
+	if err != nil {
+		return state.Block{}, fmt.Errorf("couldn't mine block. %s", err.Error())
+	}
+	logMinedBlock(hash, block, time.Since(start))
+	return block, nil
+}
 
+func logMinedBlock(hash state.Hash, block state.Block, elapsed time.Duration) {
 	logrus.Infof("\nMined new Block '%v':\n", info(fmt.Sprint(hash)))
 	logrus.Infof("\tHeight: '%v'\n", info(fmt.Sprint(block.Header.Number)))
 	logrus.Infof("\tNonce: '%v'\n", info(fmt.Sprint(block.Header.Nonce)))
+	logrus.Infof("\tDifficulty: '%v'\n", info(fmt.Sprint(block.Header.Difficulty)))
 	logrus.Infof("\tCreated: '%v'\n", info(fmt.Sprint(block.Header.Time)))
 	logrus.Infof("\tMiner: '%v'\n", info(fmt.Sprint(block.Header.Miner)))
 	logrus.Infof("\tParent: '%v'\n\n", info(fmt.Sprint(block.Header.Parent.Hex())))
-	logrus.Infof("\tAttempt: '%v'\n", info(fmt.Sprint(attempt)))
-	logrus.Infof("\tTime: %s\n\n", info(fmt.Sprint(time.Since(start))))
-
-	return block, nil
+	logrus.Infof("\tAttempt: '%v'\n", info(fmt.Sprint(block.Header.PoW)))
+	logrus.Infof("\tTime: %s\n\n", info(fmt.Sprint(elapsed)))
 }
 
 func info(msg string) string {
@@ -15,7 +15,7 @@ func TestValidBlockHash(t *testing.T) {
 	hexHash := "000000a293498234821349823482349823dffa"
 	var hash = state.Hash{}
 	hex.Decode(hash[:], []byte(hexHash))
-	isValid := state.IsBlockHashValid(hash)
+	isValid := state.IsBlockHashValid(hash, state.DefaultDifficulty)
 	if !isValid {
 		t.Fatalf("hash '%s' with 6 zeroes should be valid", hexHash)
 	}
@@ -25,8 +25,8 @@ func TestInvalidBlockHash(t *testing.T) {
 	hexHash := "999999999"
 	var hash = state.Hash{}
 	hex.Decode(hash[:], []byte(hexHash))
-	isValid := state.IsBlockHashValid(hash)
-	if !isValid {
+	isValid := state.IsBlockHashValid(hash, state.DefaultDifficulty)
+	if isValid {
 		t.Fatalf("hash '%s' should not be valid", hexHash)
 	}
 }
@@ -35,7 +35,7 @@ func TestMine(t *testing.T) {
 	miner := state.NewAddress("tony")
 	pendingBlock := createRandomPendingBlock(miner)
 	ctx := context.Background()
-	minedBlock, err := Mine(ctx, pendingBlock)
+	minedBlock, err := Mine(ctx, newTestEngine(), nil, pendingBlock)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -43,7 +43,7 @@ func TestMine(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !state.IsBlockHashValid(mineBlockHash) {
+	if !state.IsBlockHashValid(mineBlockHash, minedBlock.Header.Difficulty) {
 		t.Fatal()
 	}
 	if minedBlock.Header.Miner != miner {
@@ -55,14 +55,43 @@ func TestMineWithTimeout(t *testing.T) {
 	miner := state.NewAddress("andrej")
 	pendingBlock := createRandomPendingBlock(miner)
 
-	ctx, _ := context.WithTimeout(context.Background(), time.Microsecond*100)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Microsecond*100)
+	defer cancel()
 
-	_, err := Mine(ctx, pendingBlock)
+	_, err := Mine(ctx, newTestEngine(), nil, pendingBlock)
 	if err == nil {
 		t.Fatal(err)
 	}
 }
 
+func TestMineInstantSeal(t *testing.T) {
+	miner := state.NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	tx := state.NewSignedTx(state.NewTx(miner, "topic", 1), nil)
+	pendingBlock := NewPendingBlock(state.Hash{}, state.Hash{}, 1, miner, []state.SignedTx{tx})
+	engine := state.NewInstantSeal()
+
+	minedBlock, err := Mine(context.Background(), engine, nil, pendingBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockHash, err := minedBlock.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.VerifyHeader(nil, minedBlock.Header, blockHash); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func createRandomPendingBlock(miner common.Address) PendingBlock {
-	return NewPendingBlock(state.Hash{}, 0, miner, []state.SignedTx{})
+	return NewPendingBlock(state.Hash{}, state.Hash{}, 0, miner, []state.SignedTx{})
+}
+
+func newTestEngine() state.Engine {
+	// low enough that tests mine a block quickly, high enough that it takes more than a few hashes
+	return state.NewProofOfWork(state.Genesis{
+		Difficulty:       1 << 16,
+		BlockTime:        state.DefaultBlockTime,
+		RetargetInterval: state.DefaultRetargetInterval,
+	})
 }
@@ -3,11 +3,15 @@ package node
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/driemworks/mercury-blockchain/core"
+	"github.com/driemworks/mercury-blockchain/mempool"
 	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/driemworks/mercury-blockchain/wallet"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/sirupsen/logrus"
 
@@ -23,17 +27,30 @@ type Node struct {
 	ip              string
 	port            uint64
 	miner           common.Address
+	coinbase        common.Address
 	state           *state.State
-	pendingTXs      map[string]state.SignedTx
-	archivedTXs     map[string]state.SignedTx
+	mempool         *mempool.Mempool
 	newSyncedBlocks chan state.Block
 	newMinedBlocks  chan core.MessageTransport
 	newPendingTXs   chan core.MessageTransport
 	isMining        bool
+	miningEnabled   bool
+	cancelMining    context.CancelFunc
+	minerMu         sync.Mutex
 	name            string
 	tls             bool
-	host            host.Host
-	pubsub          *pubsub.PubSub
+	sealerPassword  string
+	devMode         bool
+	minerThreads    int
+	rewardSplit     []state.RewardShare
+	// empty blocks are mined when no block was added for this long, 0 to never mine empty blocks
+	emptyBlockInterval time.Duration
+	work               *workPackages
+	sealNow            chan struct{}
+	host               host.Host
+	pubsub             *pubsub.PubSub
+	// the port of the admin rpc server on localhost, 0 to not serve it
+	adminRPCPort uint64
 }
 
 func NewNode(name string, datadir string, miner string, ip string, port uint64, tls bool) *Node {
@@ -42,31 +59,50 @@ func NewNode(name string, datadir string, miner string, ip string, port uint64,
 		name:            name,
 		datadir:         datadir,
 		miner:           minerAddress,
+		coinbase:        minerAddress,
 		ip:              ip,
 		port:            port,
-		pendingTXs:      make(map[string]state.SignedTx),
-		archivedTXs:     make(map[string]state.SignedTx),
 		newSyncedBlocks: make(chan state.Block),
 		newMinedBlocks:  make(chan core.MessageTransport),
 		newPendingTXs:   make(chan core.MessageTransport, 10000),
+		sealNow:         make(chan struct{}, 1),
+		work:            newWorkPackages(),
 		isMining:        false,
 		tls:             tls,
 	}
 }
 
 func (n *Node) Run(ctx context.Context, ip string, port int, rpcHost string, rpcPort uint64, peer string, name string) error {
+	mempoolConfig := mempool.DefaultConfig()
+	mempoolConfig.Journal = state.GetMempoolJournalFilePath(n.datadir)
 	state, err := state.NewStateFromDisk(n.datadir)
 	if err != nil {
 		return err
 	}
 	defer state.Close()
 	n.state = state
+	n.mempool = mempool.New(state, mempoolConfig)
+	if err := n.mempool.Load(); err != nil {
+		return err
+	}
+	defer n.mempool.Close()
+	if err := n.configureEngine(); err != nil {
+		return err
+	}
 	go func() {
 		err := n.runRPCServer("", "", rpcHost, rpcPort)
 		if err != nil {
 			log.Fatalln(err)
 		}
 	}()
+	if n.adminRPCPort != 0 {
+		go func() {
+			err := n.runAdminRPCServer(n.adminRPCPort)
+			if err != nil {
+				log.Fatalln(err)
+			}
+		}()
+	}
 	go func() {
 		err := n.mine(ctx)
 		if err != nil {
@@ -80,33 +116,268 @@ func (n *Node) Run(ctx context.Context, ip string, port int, rpcHost string, rpc
 	return nil
 }
 
-func (n *Node) mine(ctx context.Context) error {
-	var miningCtx context.Context
-	var stopCurrentMining context.CancelFunc
+/*
+ Set the password of the miner's keystore account, used to seal proof of authority blocks
+*/
+func (n *Node) SetSealerPassword(password string) {
+	n.sealerPassword = password
+}
+
+/*
+ In dev mode a block is sealed as soon as a pending tx is accepted, rather than on the mining ticker
+*/
+func (n *Node) SetDevMode(devMode bool) {
+	n.devMode = devMode
+}
+
+/*
+ Serve the admin rpcs, which control the miner, on localhost at 'port'. 0 to not serve them.
+*/
+func (n *Node) SetAdminRPCPort(port uint64) {
+	n.adminRPCPort = port
+}
+
+/*
+ Set the number of nonce search workers used by proof of work, 0 for one per CPU
+*/
+func (n *Node) SetMinerThreads(threads int) {
+	n.minerThreads = threads
+}
+
+/*
+ Apply the node's mining settings to the consensus engine of the chain
+*/
+func (n *Node) configureEngine() error {
+	// dev mode seals a block as soon as a tx is added, which only a dev chain can do
+	if _, ok := n.state.Engine().(*state.InstantSeal); n.devMode && !ok {
+		return fmt.Errorf("dev mode needs a dev genesis, '%s' is initialised with the genesis of another chain", n.datadir)
+	}
+	switch engine := n.state.Engine().(type) {
+	case *state.ProofOfWork:
+		engine.SetThreads(n.minerThreads)
+	case *state.ProofOfAuthority:
+		// unlock the miner's keystore account for sealing
+		if n.sealerPassword == "" {
+			logrus.Warnln("No sealer password given, this node will not seal proof of authority blocks")
+			return nil
+		}
+		signFn, err := wallet.NewKeystoreSignerFn(n.miner, n.sealerPassword, wallet.GetKeystoreDirPath(n.datadir))
+		if err != nil {
+			return fmt.Errorf("couldn't unlock the sealer account '%s'. %s", n.miner.Hex(), err)
+		}
+		engine.Authorize(n.miner, signFn)
+		logrus.Infof("Sealing proof of authority blocks as '%s'\n", n.miner.Hex())
+	}
+	return nil
+}
+
+/*
+ Mine an empty block whenever no block was added for 'interval', so the chain keeps moving
+ without txs. An interval of 0 only mines blocks with pending txs.
+*/
+func (n *Node) SetEmptyBlockInterval(interval time.Duration) {
+	n.emptyBlockInterval = interval
+}
+
+/*
+ True if the node mines empty blocks and no block was added for the empty block interval
+*/
+func (n *Node) isEmptyBlockDue() bool {
+	if n.emptyBlockInterval <= 0 {
+		return false
+	}
+	latest := n.state.LatestBlock()
+	if latest.Header.Number == 0 && n.state.LatestBlockHash().IsEmpty() {
+		return true
+	}
+	return time.Since(time.Unix(int64(latest.Header.Time), 0)) >= n.emptyBlockInterval
+}
+
+/*
+ Split the rewards of the blocks this node mines across several addresses by weight,
+ instead of paying it all to the coinbase
+*/
+func (n *Node) SetRewardSplit(shares []state.RewardShare) {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	n.rewardSplit = shares
+}
+
+func (n *Node) rewardShares(coinbase common.Address) []state.RewardShare {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	if len(n.rewardSplit) == 0 {
+		return []state.RewardShare{{To: coinbase, Weight: 1}}
+	}
+	return n.rewardSplit
+}
 
+/*
+ Start mining blocks with the node's coinbase address
+*/
+func (n *Node) StartMining() error {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	if (n.coinbase == common.Address{}) {
+		return fmt.Errorf("set a coinbase address before mining")
+	}
+	if !n.miningEnabled {
+		logrus.Infof("Mining started with coinbase '%s'\n", n.coinbase.Hex())
+	}
+	n.miningEnabled = true
+	return nil
+}
+
+/*
+ Stop mining, abandoning the block being mined
+*/
+func (n *Node) StopMining() {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	if n.miningEnabled {
+		logrus.Infoln("Mining stopped")
+	}
+	n.miningEnabled = false
+	if n.cancelMining != nil {
+		n.cancelMining()
+	}
+}
+
+func (n *Node) IsMining() bool {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	return n.miningEnabled
+}
+
+/*
+ The address that receives the rewards of the blocks this node mines
+*/
+func (n *Node) Coinbase() common.Address {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	return n.coinbase
+}
+
+/*
+ The node's account, which signs the txs the node sends. It is set when the node is created
+ and doesn't follow the coinbase.
+*/
+func (n *Node) Account() common.Address {
+	return n.miner
+}
+
+/*
+ Change the coinbase address. The block being mined is abandoned and mining continues with the new address.
+ The node's txs are still signed by its account.
+*/
+func (n *Node) SetCoinbase(coinbase common.Address) error {
+	if _, ok := n.state.Engine().(*state.ProofOfAuthority); ok {
+		return fmt.Errorf("the coinbase of a proof of authority signer can't be changed while running")
+	}
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	n.coinbase = coinbase
+	if n.cancelMining != nil {
+		n.cancelMining()
+	}
+	logrus.Infof("Coinbase set to '%s'\n", coinbase.Hex())
+	return nil
+}
+
+/*
+ A ctx for mining one block, cancelled by StopMining, SetCoinbase or a peer's block
+*/
+func (n *Node) newMiningContext(ctx context.Context) context.Context {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	miningCtx, cancel := context.WithCancel(ctx)
+	n.cancelMining = cancel
+	return miningCtx
+}
+
+func (n *Node) stopCurrentMining() {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	if n.cancelMining != nil {
+		n.cancelMining()
+	}
+}
+
+/*
+ Claim the current mining round, only one round of sealing runs at a time
+*/
+func (n *Node) startMiningRound() bool {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	if n.isMining || !n.miningEnabled {
+		return false
+	}
+	n.isMining = true
+	return true
+}
+
+func (n *Node) endMiningRound() {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	n.isMining = false
+}
+
+func (n *Node) isMiningRound() bool {
+	n.minerMu.Lock()
+	defer n.minerMu.Unlock()
+	return n.isMining
+}
+
+func (n *Node) mine(ctx context.Context) error {
 	ticker := time.NewTicker(time.Second * miningIntervalSeconds)
+	var heartbeat <-chan time.Time
+	if n.emptyBlockInterval > 0 {
+		heartbeatTicker := time.NewTicker(n.emptyBlockInterval)
+		defer heartbeatTicker.Stop()
+		heartbeat = heartbeatTicker.C
+	}
 
 	for {
 		select {
 		case <-ticker.C:
+			n.mempool.RemoveExpired()
 			go func() {
-				if len(n.pendingTXs) > 0 && !n.isMining {
-					n.isMining = true
-					miningCtx, stopCurrentMining = context.WithCancel(ctx)
-					err := n.minePendingTXs(miningCtx)
+				if n.mempool.Len() > 0 && n.startMiningRound() {
+					err := n.minePendingTXs(n.newMiningContext(ctx))
 					if err != nil {
 						logrus.Errorln(err)
 					}
-					n.isMining = false
+					n.endMiningRound()
 				}
 			}()
 
+		case <-heartbeat:
+			go func() {
+				if n.mempool.Len() == 0 && n.isEmptyBlockDue() && n.startMiningRound() {
+					logrus.Infoln("No pending TXs, mining an empty block")
+					err := n.minePendingTXs(n.newMiningContext(ctx))
+					if err != nil {
+						logrus.Errorln(err)
+					}
+					n.endMiningRound()
+				}
+			}()
+
+		case <-n.sealNow:
+			// dev mode only runs on a dev chain where sealing is instant, so the block is built in the loop
+			if n.startMiningRound() {
+				err := n.minePendingTXs(n.newMiningContext(ctx))
+				if err != nil {
+					logrus.Errorln(err)
+				}
+				n.endMiningRound()
+			}
+
 		case block, _ := <-n.newSyncedBlocks:
-			if n.isMining {
+			if n.isMiningRound() {
 				blockHash, _ := block.Hash()
-				logrus.Infoln("Peer mined next Block '%s' faster :(\n", rainbow.Yellow(blockHash.Hex()))
-				n.removeMinedPendingTXs(block)
-				stopCurrentMining()
+				logrus.Infof("Peer mined next Block '%s' faster :(\n", rainbow.Yellow(blockHash.Hex()))
+				n.stopCurrentMining()
 			}
 		case <-ctx.Done():
 			ticker.Stop()
@@ -116,92 +387,113 @@ func (n *Node) mine(ctx context.Context) error {
 }
 
 func (n *Node) minePendingTXs(ctx context.Context) error {
-	blockToMine := NewPendingBlock(
-		n.state.LatestBlockHash(),
-		n.state.NextBlockNumber(),
-		n.miner,
-		n.getPendingTXsAsArray(),
-	)
-	minedBlock, err := Mine(ctx, blockToMine)
+	blockToMine, err := n.newPendingBlock()
+	if err != nil {
+		return err
+	}
+	minedBlock, err := Mine(ctx, n.state.Engine(), n.state, blockToMine)
 	if err != nil {
 		return err
 	}
+	return n.commitMinedBlock(minedBlock)
+}
 
-	n.removeMinedPendingTXs(minedBlock)
-	_, _, err = n.state.AddBlock(minedBlock)
+/*
+ The pending block of the pending TXs on top of the current chain
+*/
+func (n *Node) newPendingBlock() (PendingBlock, error) {
+	txs := selectTXsByFee(n.mempool.Pending(), maxBlockTXs)
+	coinbase := n.Coinbase()
+	// the whole block is built on one copy of the state, blocks may be added to the chain meanwhile
+	head := n.state.Copy()
+	if reward, ok := head.NextBlockReward(); ok {
+		payouts := state.SplitReward(reward, n.rewardShares(coinbase))
+		txs = append([]state.SignedTx{state.NewCoinbaseTx(head.NextBlockNumber(), payouts)}, txs...)
+	}
+	// one invalid tx would make the whole block invalid, so only the txs that apply are included
+	txs, dropped := head.SimulateTXs(txs)
+	for _, d := range dropped {
+		txHash, _ := d.TX.Hash()
+		logrus.Warnf("Leaving TX %s out of the block: %s\n", rainbow.Yellow(txHash.Hex()), d.Reason)
+		// a tx that failed on the state, e.g. on its nonce, may still apply to a later block
+		if d.Permanent {
+			n.mempool.Remove(txHash, d.Reason)
+		}
+	}
+	stateRoot, err := head.NextStateRoot(coinbase, txs)
+	if err != nil {
+		return PendingBlock{}, err
+	}
+	pendingBlock := NewPendingBlock(
+		head.LatestBlockHash(),
+		stateRoot,
+		head.NextBlockNumber(),
+		coinbase,
+		txs,
+	)
+	pendingBlock.dropped = dropped
+	return pendingBlock, nil
+}
+
+/*
+ Add a block this node sealed to the chain and broadcast it to peers
+*/
+func (n *Node) commitMinedBlock(block state.Block) error {
+	_, err := n.addBlock(block)
 	if err != nil {
 		return err
 	}
-	blockBytes, err := json.Marshal(minedBlock)
+	blockBytes, err := json.Marshal(block)
 	if err != nil {
 		return err
 	}
-	n.newMinedBlocks <- core.MessageTransport{blockBytes}
+	n.newMinedBlocks <- core.MessageTransport{Data: blockBytes}
 	return nil
 }
 
-func (n *Node) removeMinedPendingTXs(block state.Block) {
-	if len(block.TXs) > 0 && len(n.pendingTXs) > 0 {
-		logrus.Infoln("Updating in-memory Pending TXs Pool")
-	}
-
-	for _, tx := range block.TXs {
-		txHash, _ := tx.Hash()
-		if _, exists := n.pendingTXs[txHash.Hex()]; exists {
-			logrus.Infof("Archiving mined TX: %s\n", rainbow.Yellow(txHash.Hex()))
-			n.archivedTXs[txHash.Hex()] = tx
-			delete(n.pendingTXs, txHash.Hex())
-		}
+/*
+ Add the block to the chain and update the pending TXs pool with the change to the canonical chain
+*/
+func (n *Node) addBlock(block state.Block) (state.ChainUpdate, error) {
+	update, err := n.state.AddBlock(block)
+	if err != nil {
+		return update, err
 	}
+	n.mempool.Update(update)
+	return update, nil
 }
 
-/**
-Add a pending transaction to the node's pending transactions array
+/*
+ *
+ Add a pending transaction to the node's mempool
 */
 func (n *Node) AddPendingTX(tx state.SignedTx) error {
-	txHash, err := tx.Hash()
+	err := n.mempool.Add(tx)
+	if err == mempool.ErrKnownTx {
+		return nil
+	}
+	if err != nil {
+		txHash, _ := tx.Hash()
+		return fmt.Errorf("rejected pending TX %s. %s", txHash.Hex(), err.Error())
+	}
+	txJSON, err := json.Marshal(tx)
 	if err != nil {
 		return err
 	}
-
-	_, isAlreadyPending := n.pendingTXs[txHash.Hex()]
-	_, isArchived := n.archivedTXs[txHash.Hex()]
-
-	if !isAlreadyPending && !isArchived {
-		txJSON, err := json.Marshal(tx)
-		if err != nil {
-			return err
-		}
-		prettyTxJSON, err := core.PrettyPrintJSON(txJSON)
-		if err != nil {
-			return err
+	prettyTxJSON, err := core.PrettyPrintJSON(txJSON)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Added pending transaction: \n%s\n", &prettyTxJSON)
+	if n.devMode {
+		select {
+		case n.sealNow <- struct{}{}:
+		default:
 		}
-
-		logrus.Infof("Adding pending transactions: \n%s\n", &prettyTxJSON)
-		tmpFrom := n.state.Catalog[tx.Author]
-		// if tmpFrom.Balance <= 0 {
-		// 	// for now...
-		// 	tmpFrom.Balance = 10
-		// 	// return fmt.Errorf("Insufficient balance")
-		// }
-		tmpFrom.Balance -= 1
-		n.pendingTXs[txHash.Hex()] = tx
-		n.state.Catalog[tx.Author] = tmpFrom
-		n.state.PendingAccount2Nonce[tx.Author]++
 	}
 	return nil
 }
 
-func (n *Node) getPendingTXsAsArray() []state.SignedTx {
-	txs := make([]state.SignedTx, len(n.pendingTXs))
-	i := 0
-	for _, tx := range n.pendingTXs {
-		txs[i] = tx
-		i++
-	}
-	return txs
-}
-
 func (n *Node) Join(ctx context.Context, topicName string,
 	bufSize int, onMessage core.MessageHandler,
 	msgChan chan core.MessageTransport) error {
@@ -0,0 +1,228 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/driemworks/mercury-blockchain/mempool"
+	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/driemworks/mercury-blockchain/wallet"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiningControl(t *testing.T) {
+	n, cleanup := newTestWorkNode(t)
+	defer cleanup()
+	coinbase := n.Coinbase()
+
+	account := n.Account()
+
+	// an observer node without a coinbase can't mine
+	n.coinbase = state.NewAddress("")
+	assert.NotNil(t, n.StartMining())
+	assert.False(t, n.IsMining())
+
+	assert.Nil(t, n.SetCoinbase(coinbase))
+	assert.Nil(t, n.StartMining())
+	assert.True(t, n.IsMining())
+
+	// stopping cancels the block being mined
+	miningCtx := n.newMiningContext(context.Background())
+	n.StopMining()
+	assert.False(t, n.IsMining())
+	assert.NotNil(t, miningCtx.Err())
+
+	// changing the coinbase restarts the block being mined with the new address
+	miningCtx = n.newMiningContext(context.Background())
+	other := state.NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	assert.Nil(t, n.SetCoinbase(other))
+	assert.NotNil(t, miningCtx.Err())
+	pendingBlock, err := n.newPendingBlock()
+	assert.Nil(t, err)
+	assert.Equal(t, other, pendingBlock.miner)
+	// the node's txs are still sent from its account
+	assert.Equal(t, account, n.Account())
+}
+
+func TestDevModeNeedsDevGenesis(t *testing.T) {
+	n, cleanup := newTestWorkNode(t)
+	defer cleanup()
+
+	// a proof of work chain can't seal blocks instantly
+	n.SetDevMode(true)
+	assert.NotNil(t, n.configureEngine())
+	n.SetDevMode(false)
+	assert.Nil(t, n.configureEngine())
+}
+
+func TestEmptyBlockHeartbeat(t *testing.T) {
+	n, cleanup := newTestWorkNode(t)
+	defer cleanup()
+	n.mempool = mempool.New(n.state, mempool.DefaultConfig())
+
+	// without an interval nothing is mined until there are txs
+	assert.False(t, n.isEmptyBlockDue())
+	_, _, err := n.GetWork()
+	assert.NotNil(t, err)
+
+	n.SetEmptyBlockInterval(time.Hour)
+	assert.True(t, n.isEmptyBlockDue())
+	workID, template, err := n.GetWork()
+	assert.Nil(t, err)
+	// only the coinbase
+	assert.Len(t, template.TXs, 1)
+	_, err = n.SubmitWork(workID, 0, 0, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), n.state.LatestBlock().Header.Number)
+
+	// the block was just added, so the next empty block isn't due for an hour
+	assert.False(t, n.isEmptyBlockDue())
+	n.SetEmptyBlockInterval(time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	assert.True(t, n.isEmptyBlockDue())
+}
+
+func TestOneMiningRoundAtATime(t *testing.T) {
+	n, cleanup := newTestWorkNode(t)
+	defer cleanup()
+
+	// nothing is mined while mining is stopped
+	assert.False(t, n.startMiningRound())
+
+	assert.Nil(t, n.StartMining())
+	claimed := make(chan bool, 10)
+	for i := 0; i < cap(claimed); i++ {
+		go func() { claimed <- n.startMiningRound() }()
+	}
+	rounds := 0
+	for i := 0; i < cap(claimed); i++ {
+		if <-claimed {
+			rounds++
+		}
+	}
+	assert.Equal(t, 1, rounds)
+	assert.True(t, n.isMiningRound())
+
+	n.endMiningRound()
+	assert.True(t, n.startMiningRound())
+	n.endMiningRound()
+}
+
+func TestAddPendingTXEnforcesBalance(t *testing.T) {
+	n, key, cleanup := newTestWorkNodeWithKey(t)
+	defer cleanup()
+	author := n.Coinbase()
+
+	// the pending tx spent one of the author's two coins, without touching the committed balance
+	assert.Equal(t, state.CoinUnit, n.mempool.Balance(author))
+	assert.Equal(t, 2*state.CoinUnit, n.state.Catalog[author].Balance)
+	other := state.NewAddress("0x0000000000000000000000000000000000000001")
+	unpaid, err := wallet.SignTx(state.NewTransferTx(author, other, state.CoinUnit+1, 2), n.state.ChainID(), key)
+	assert.Nil(t, err)
+	assert.NotNil(t, n.AddPendingTX(unpaid))
+	assert.Equal(t, 1, n.mempool.Len())
+
+	// a tx signed for another chain is rejected
+	replayed, err := wallet.SignTx(state.NewTransferTx(author, other, 1, 2), "other-chain", key)
+	assert.Nil(t, err)
+	assert.NotNil(t, n.AddPendingTX(replayed))
+	assert.Equal(t, 1, n.mempool.Len())
+
+	// mining the block pays the reward, which the author can spend
+	workID, _, err := n.GetWork()
+	assert.Nil(t, err)
+	_, err = n.SubmitWork(workID, 0, 0, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 11*state.CoinUnit, n.mempool.Balance(author))
+	assert.Equal(t, uint(1), n.mempool.Nonce(author))
+}
+
+func TestNewPendingBlockDropsInvalidTXs(t *testing.T) {
+	n, key, cleanup := newTestWorkNodeWithKey(t)
+	defer cleanup()
+	author := n.Account()
+
+	// the pending tx was valid when it was added but its nonce has since been used
+	n.state.Account2Nonce[author] = 1
+	pendingBlock, err := n.newPendingBlock()
+	assert.Nil(t, err)
+	// only the coinbase
+	assert.Len(t, pendingBlock.txs, 1)
+	assert.Len(t, pendingBlock.dropped, 1)
+	assert.NotNil(t, pendingBlock.dropped[0].Reason)
+	// a nonce failure depends on the state, so the tx stays in the pool
+	assert.False(t, pendingBlock.dropped[0].Permanent)
+	assert.Equal(t, 1, n.mempool.Len())
+
+	// a block with the next nonce is accepted
+	tx, err := wallet.SignTx(state.NewTx(author, "topic", 2), n.state.ChainID(), key)
+	assert.Nil(t, err)
+	assert.Nil(t, n.AddPendingTX(tx))
+	workID, template, err := n.GetWork()
+	assert.Nil(t, err)
+	assert.Len(t, template.TXs, 2)
+	_, err = n.SubmitWork(workID, 0, 0, 1)
+	assert.Nil(t, err)
+}
+
+func TestNewPendingBlockKeepsReplacedTXs(t *testing.T) {
+	n, key, cleanup := newTestWorkNodeWithKey(t)
+	defer cleanup()
+	author := n.Account()
+	to := state.NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+
+	// the replacement of the account's first tx is signed after its next tx
+	next := state.NewTransferTx(author, to, 1, 2)
+	next.Time--
+	nextTX, err := wallet.SignTx(next, n.state.ChainID(), key)
+	assert.Nil(t, err)
+	assert.Nil(t, n.AddPendingTX(nextTX))
+	replacement, err := wallet.SignTx(state.NewTransferTx(author, to, 1, 1).WithFee(10), n.state.ChainID(), key)
+	assert.Nil(t, err)
+	assert.Nil(t, n.AddPendingTX(replacement))
+
+	pendingBlock, err := n.newPendingBlock()
+	assert.Nil(t, err)
+	assert.Empty(t, pendingBlock.dropped)
+	// the coinbase, then the account's txs in nonce order
+	assert.Equal(t, []state.SignedTx{replacement, nextTX}, pendingBlock.txs[1:])
+	assert.Equal(t, 2, n.mempool.Len())
+
+	// and the block applies
+	workID, template, err := n.GetWork()
+	assert.Nil(t, err)
+	assert.Len(t, template.TXs, 3)
+	_, err = n.SubmitWork(workID, 0, 0, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n.mempool.Len())
+}
+
+func TestAddPendingTXWhileAddingBlocks(t *testing.T) {
+	n, key, cleanup := newTestWorkNodeWithKey(t)
+	defer cleanup()
+	author := n.Coinbase()
+	n.SetEmptyBlockInterval(time.Hour)
+
+	// the mempool reads the committed nonces and balances while blocks replace them,
+	// run with -race to check it does so under the state's lock
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for nonce := uint(2); nonce < 50; nonce++ {
+			tx, err := wallet.SignTx(state.NewTx(author, "topic", nonce), n.state.ChainID(), key)
+			assert.Nil(t, err)
+			n.AddPendingTX(tx)
+			n.state.Account(author)
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		workID, _, err := n.GetWork()
+		assert.Nil(t, err)
+		_, err = n.SubmitWork(workID, 0, 0, 1)
+		assert.Nil(t, err)
+	}
+	<-done
+	assert.True(t, n.state.Nonce(author) > 0)
+}
@@ -0,0 +1,41 @@
+package node
+
+import (
+	"github.com/driemworks/mercury-blockchain/state"
+)
+
+/*
+ The receipt of a tx: mined in a block of the canonical chain, pending in the mempool,
+ or dropped with the reason why. A tx the node has never seen has the status unknown.
+*/
+func (n *Node) GetReceipt(txHash state.Hash) state.Receipt {
+	receipt, ok := n.state.GetReceipt(txHash)
+	if ok && receipt.Status == state.TxStatusMined {
+		return receipt
+	}
+	// a dropped tx can be added again, so the pool wins over an old failed receipt
+	if _, pending := n.mempool.Get(txHash); pending {
+		return state.Receipt{TxHash: txHash, Status: state.TxStatusPending}
+	}
+	if ok {
+		return receipt
+	}
+	return state.Receipt{TxHash: txHash, Status: state.TxStatusUnknown}
+}
+
+/*
+ The tx with the given hash and its receipt. The tx itself is only known while it is pending or once it is mined.
+*/
+func (n *Node) GetTransaction(txHash state.Hash) (state.SignedTx, bool, state.Receipt, error) {
+	tx, receipt, ok, err := n.state.GetMinedTx(txHash)
+	if err != nil {
+		return state.SignedTx{}, false, state.Receipt{}, err
+	}
+	if ok {
+		return tx, true, receipt, nil
+	}
+	if tx, ok := n.mempool.Get(txHash); ok {
+		return tx, true, state.Receipt{TxHash: txHash, Status: state.TxStatusPending}, nil
+	}
+	return state.SignedTx{}, false, n.GetReceipt(txHash), nil
+}
@@ -0,0 +1,53 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/driemworks/mercury-blockchain/wallet"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReceipt(t *testing.T) {
+	n, key, cleanup := newTestWorkNodeWithKey(t)
+	defer cleanup()
+	author := n.Coinbase()
+	pendingTX := n.mempool.Pending()[0]
+	pendingHash, err := pendingTX.Hash()
+	assert.Nil(t, err)
+
+	assert.Equal(t, state.TxStatusUnknown, n.GetReceipt(state.Hash{1}).Status)
+	tx, ok, receipt, err := n.GetTransaction(pendingHash)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, pendingTX, tx)
+	assert.Equal(t, state.TxStatusPending, receipt.Status)
+
+	// the mined tx comes after the coinbase in the block
+	workID, _, err := n.GetWork()
+	assert.Nil(t, err)
+	minedBlock, err := n.SubmitWork(workID, 0, 0, 1)
+	assert.Nil(t, err)
+	tx, ok, receipt, err = n.GetTransaction(pendingHash)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, pendingTX, tx)
+	assert.Equal(t, state.Receipt{TxHash: pendingHash, Status: state.TxStatusMined,
+		BlockHash: minedBlock, Height: 1, Index: 1}, receipt)
+
+	// a tx replaced by one paying a higher fee fails with the reason
+	replaced, err := wallet.SignTx(state.NewTx(author, "replaced", 2), n.state.ChainID(), key)
+	assert.Nil(t, err)
+	assert.Nil(t, n.AddPendingTX(replaced))
+	replacement, err := wallet.SignTx(state.NewTx(author, "replaced", 2).WithFee(1), n.state.ChainID(), key)
+	assert.Nil(t, err)
+	assert.Nil(t, n.AddPendingTX(replacement))
+	replacedHash, err := replaced.Hash()
+	assert.Nil(t, err)
+	_, ok, receipt, err = n.GetTransaction(replacedHash)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, state.TxStatusFailed, receipt.Status)
+	assert.NotEmpty(t, receipt.Reason)
+}
@@ -40,6 +40,24 @@ func (n *Node) runRPCServer(certFile string, keyFile string, host string, port u
 	return nil
 }
 
+// the admin rpcs only listen on localhost, so only the node's operator can control its miner
+const adminRPCHost = "127.0.0.1"
+
+/**
+Run an RPC server on localhost to serve the implementation of the AdminServer
+*/
+func (n *Node) runAdminRPCServer(port uint64) error {
+	grpcServer := grpc.NewServer()
+	pb.RegisterAdminServiceServer(grpcServer, adminServer{node: n})
+	reflection.Register(grpcServer)
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", adminRPCHost, port))
+	if err != nil {
+		return err
+	}
+	log.Infoln(fmt.Sprintf("Admin RPC server listening on: %s:%d", adminRPCHost, port))
+	return grpcServer.Serve(lis)
+}
+
 func loadTLSCredentials() (credentials.TransportCredentials, error) {
 	serverCert, err := tls.LoadX509KeyPair("resources/cert/server-cert.pem", "resources/cert/server-key.pem")
 	if err != nil {
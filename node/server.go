@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -10,6 +11,7 @@ import (
 	pb "github.com/driemworks/mercury-blockchain/proto"
 	"github.com/driemworks/mercury-blockchain/state"
 	"github.com/driemworks/mercury-blockchain/wallet"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 )
@@ -21,13 +23,14 @@ type nodeServer struct {
 
 func (server nodeServer) GetNodeStatus(
 	ctx context.Context, statusRequest *pb.NodeInfoRequest) (*pb.NodeInfoResponse, error) {
-	nodeState := server.node.state.Catalog[server.node.miner]
+	account := server.node.Account()
+	nodeState := server.node.state.Account(account)
 	var channels []string
 	for _, bytes := range nodeState.OwnedChannels {
 		channels = append(channels, string(bytes))
 	}
 	return &pb.NodeInfoResponse{
-		Address:  server.node.miner.Hex(),
+		Address:  account.Hex(),
 		Balance:  nodeState.Balance,
 		Channels: channels,
 	}, nil
@@ -55,7 +58,7 @@ func (server nodeServer) Subscribe(
 func (server nodeServer) Publish(
 	ctx context.Context, publishRequest *pb.PublishRequest) (*pb.PublishResponse, error) {
 	if dataChan := server.node.state.Subscriptions[publishRequest.TxHash]; dataChan != nil {
-		dataChan <- core.MessageTransport{[]byte(publishRequest.Message)}
+		dataChan <- core.MessageTransport{Data: []byte(publishRequest.Message)}
 	} else {
 		return &pb.PublishResponse{Message: "You must first be subscribed to the topic"}, nil
 	}
@@ -91,63 +94,256 @@ func (server nodeServer) ListBlocks(
 	if err != nil {
 		return err
 	}
-	blocks, err := state.GetBlocksAfter(hash, server.node.datadir)
+	blocks, err := server.node.state.GetBlocksAfter(hash)
 	if err != nil {
 		return err
 	}
 
 	for _, block := range blocks {
-		blockHeader := pb.BlockHeaderMessage{}
+		blockHeader := toBlockHeaderMessage(block.Header)
 		txs := make([]*pb.TransactionMessage, 0)
 		for _, t := range block.TXs {
-			hash, err := t.Hash()
+			txMessage, err := toTransactionMessage(t)
 			if err != nil {
 				log.Fatalln("failed to hash the tx: ", err)
 			}
-			txMessage := pb.TransactionMessage{
-				Author: t.Author.Hex(),
-				Topic:  t.Topic,
-				Hash:   hash.Hex(),
-				// Nonce:  string(t.Nonce),
-				// Time:   string(t.Time),
-				// Signature: string(t.Sig),
-			}
 			// txs = append(txs, pb.TransactionMessage{
 			// 	t.Author, t.Topic, t.Nonce, t.Time, t.Signature,
 			// })
-			txs = append(txs, &txMessage)
+			txs = append(txs, txMessage)
 		}
 		stream.Send(&pb.BlockResponse{
-			BlockHeader: &blockHeader,
+			BlockHeader: blockHeader,
 			Txs:         txs,
 		})
 	}
 	return nil
 }
 
+/*
+	Get a merkle proof that the tx is included in a block
+*/
+func (server nodeServer) GetTxProof(
+	ctx context.Context, txProofRequest *pb.TxProofRequest) (*pb.TxProofResponse, error) {
+	txHash := state.Hash{}
+	err := txHash.UnmarshalText([]byte(txProofRequest.TxHash))
+	if err != nil {
+		return nil, err
+	}
+	proof, ok, err := server.node.state.GetTxProof(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("tx '%s' is not included in any block", txProofRequest.TxHash)
+	}
+	siblings := make([]string, len(proof.Siblings))
+	for i, sibling := range proof.Siblings {
+		siblings[i] = sibling.Hex()
+	}
+	return &pb.TxProofResponse{
+		BlockHash:   proof.BlockHash.Hex(),
+		BlockHeader: toBlockHeaderMessage(proof.Header),
+		Index:       uint64(proof.Index),
+		Siblings:    siblings,
+		TxCount:     uint64(proof.TxCount),
+	}, nil
+}
+
+/*
+	Get a tx and whether it is pending, mined or was dropped
+*/
+func (server nodeServer) GetTransaction(
+	ctx context.Context, request *pb.GetTransactionRequest) (*pb.GetTransactionResponse, error) {
+	txHash := state.Hash{}
+	if err := txHash.UnmarshalText([]byte(request.Hash)); err != nil {
+		return nil, err
+	}
+	tx, ok, receipt, err := server.node.GetTransaction(txHash)
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.GetTransactionResponse{Receipt: toReceiptMessage(receipt)}
+	if ok {
+		response.Transaction, err = toTransactionMessage(tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+/*
+	Get the receipt of a tx
+*/
+func (server nodeServer) GetReceipt(
+	ctx context.Context, request *pb.GetReceiptRequest) (*pb.ReceiptMessage, error) {
+	txHash := state.Hash{}
+	if err := txHash.UnmarshalText([]byte(request.Hash)); err != nil {
+		return nil, err
+	}
+	return toReceiptMessage(server.node.GetReceipt(txHash)), nil
+}
+
 /*
 	Read/Write pending transactions
 */
 func (server nodeServer) AddTransaction(
 	ctx context.Context, addPendingTransactionRequest *pb.AddPendingTransactionRequest) (
 	*pb.AddPendingTransactionResponse, error) {
-	nonce := server.node.state.PendingAccount2Nonce[server.node.miner] + 1
+	account := server.node.Account()
+	nonce := server.node.mempool.Nonce(account) + 1
 	tx := state.NewTx(
-		server.node.miner, addPendingTransactionRequest.Label, nonce,
-	)
+		account, addPendingTransactionRequest.Label, nonce,
+	).WithFee(addPendingTransactionRequest.Fee)
+	signedTx, err := wallet.SignTxWithKeystoreAccount(
+		tx, server.node.state.ChainID(), account, addPendingTransactionRequest.Password,
+		wallet.GetKeystoreDirPath(server.node.datadir))
+	if err != nil {
+		return nil, err
+	}
+	if err := server.node.AddPendingTX(signedTx); err != nil {
+		return nil, err
+	}
+	hash, err := signedTx.Hash()
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := json.Marshal(signedTx)
+	if err != nil {
+		return nil, err
+	}
+	server.node.newPendingTXs <- core.MessageTransport{Data: txBytes}
+	return &pb.AddPendingTransactionResponse{Hash: hash.Hex()}, nil
+}
+
+/*
+	Send tokens from the node's account to another account
+*/
+func (server nodeServer) Transfer(
+	ctx context.Context, request *pb.TransferRequest) (*pb.TransferResponse, error) {
+	if !common.IsHexAddress(request.To) {
+		return nil, fmt.Errorf("invalid recipient address '%s'", request.To)
+	}
+	if request.Amount == 0 {
+		return nil, fmt.Errorf("the amount to transfer must be positive")
+	}
+	account := server.node.Account()
+	nonce := server.node.mempool.Nonce(account) + 1
+	tx := state.NewTransferTx(account, state.NewAddress(request.To), request.Amount, nonce).WithFee(request.Fee)
+	signedTx, err := wallet.SignTxWithKeystoreAccount(
+		tx, server.node.state.ChainID(), account, request.Password,
+		wallet.GetKeystoreDirPath(server.node.datadir))
+	if err != nil {
+		return nil, err
+	}
+	if err := server.node.AddPendingTX(signedTx); err != nil {
+		return nil, err
+	}
+	hash, err := signedTx.Hash()
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := json.Marshal(signedTx)
+	if err != nil {
+		return nil, err
+	}
+	server.node.newPendingTXs <- core.MessageTransport{Data: txBytes}
+	return &pb.TransferResponse{Hash: hash.Hex()}, nil
+}
+
+/*
+	Estimate a fee that gets a tx mined soon
+*/
+func (server nodeServer) EstimateFee(
+	ctx context.Context, request *pb.EstimateFeeRequest) (*pb.EstimateFeeResponse, error) {
+	fee, err := server.node.EstimateFee()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.EstimateFeeResponse{Fee: fee}, nil
+}
+
+/*
+	Vote a proof of authority signer in or out with a tx from the node's miner
+*/
+func (server nodeServer) ProposeSigner(
+	ctx context.Context, request *pb.ProposeSignerRequest) (*pb.ProposeSignerResponse, error) {
+	if !common.IsHexAddress(request.Signer) {
+		return nil, fmt.Errorf("invalid signer address '%s'", request.Signer)
+	}
+	account := server.node.Account()
+	nonce := server.node.mempool.Nonce(account) + 1
+	tx := state.NewVoteTx(account, state.NewAddress(request.Signer), request.Authorize, nonce).WithFee(request.Fee)
 	signedTx, err := wallet.SignTxWithKeystoreAccount(
-		tx, server.node.miner, addPendingTransactionRequest.Password,
+		tx, server.node.state.ChainID(), account, request.Password,
 		wallet.GetKeystoreDirPath(server.node.datadir))
 	if err != nil {
 		return nil, err
 	}
-	server.node.AddPendingTX(signedTx)
+	if err := server.node.AddPendingTX(signedTx); err != nil {
+		return nil, err
+	}
 	txBytes, err := json.Marshal(signedTx)
 	if err != nil {
 		return nil, err
 	}
-	server.node.newPendingTXs <- core.MessageTransport{txBytes}
-	return &pb.AddPendingTransactionResponse{}, nil
+	server.node.newPendingTXs <- core.MessageTransport{Data: txBytes}
+	return &pb.ProposeSignerResponse{}, nil
+}
+
+/*
+	Hand out a block template for an external miner
+*/
+func (server nodeServer) GetWork(
+	ctx context.Context, request *pb.GetWorkRequest) (*pb.GetWorkResponse, error) {
+	workID, block, err := server.node.GetWork()
+	if err != nil {
+		return nil, err
+	}
+	blockJSON, err := json.Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+	target := state.DifficultyTarget(block.Header.Difficulty)
+	return &pb.GetWorkResponse{
+		WorkId:      workID.Hex(),
+		BlockHeader: toBlockHeaderMessage(block.Header),
+		Target:      fmt.Sprintf("%064x", target),
+		Block:       blockJSON,
+	}, nil
+}
+
+/*
+	Accept the nonce an external miner found for a work package
+*/
+func (server nodeServer) SubmitWork(
+	ctx context.Context, request *pb.SubmitWorkRequest) (*pb.SubmitWorkResponse, error) {
+	var workID state.Hash
+	if err := workID.UnmarshalText([]byte(request.WorkId)); err != nil {
+		return nil, fmt.Errorf("invalid work id '%s'", request.WorkId)
+	}
+	hash, err := server.node.SubmitWork(workID, request.Nonce, request.ExtraNonce, int(request.Pow))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SubmitWorkResponse{BlockHash: hash.Hex()}, nil
+}
+
+func (server nodeServer) GetMiningStatus(
+	ctx context.Context, request *pb.MiningStatusRequest) (*pb.MiningStatusResponse, error) {
+	return miningStatus(server.node), nil
+}
+
+func miningStatus(node *Node) *pb.MiningStatusResponse {
+	status := &pb.MiningStatusResponse{
+		Mining:   node.IsMining(),
+		Coinbase: node.Coinbase().Hex(),
+	}
+	if pow, ok := node.state.Engine().(*state.ProofOfWork); ok {
+		status.Hashrate = pow.Hashrate()
+	}
+	return status
 }
 
 // // TODO for now this is only the publish cid tx... will generalize later
@@ -169,6 +365,64 @@ func (server nodeServer) AddTransaction(
 // 	return nil
 // }
 
+func toTransactionMessage(t state.SignedTx) (*pb.TransactionMessage, error) {
+	hash, err := t.Hash()
+	if err != nil {
+		return nil, err
+	}
+	txMessage := pb.TransactionMessage{
+		Author:  t.Author.Hex(),
+		Topic:   t.Topic,
+		Hash:    hash.Hex(),
+		Type:    string(t.TxType()),
+		Fee:     t.Fee,
+		ChainId: t.ChainID,
+		// Nonce:  string(t.Nonce),
+		// Time:   string(t.Time),
+		// Signature: string(t.Sig),
+	}
+	if t.Transfer != nil {
+		txMessage.Transfer = &pb.TransferMessage{To: t.Transfer.To.Hex(), Amount: t.Transfer.Amount}
+	}
+	if t.Coinbase != nil {
+		for _, payout := range t.Coinbase.Payouts {
+			txMessage.Payouts = append(txMessage.Payouts,
+				&pb.PayoutMessage{To: payout.To.Hex(), Amount: payout.Amount})
+		}
+	}
+	return &txMessage, nil
+}
+
+func toReceiptMessage(receipt state.Receipt) *pb.ReceiptMessage {
+	message := &pb.ReceiptMessage{
+		TxHash: receipt.TxHash.Hex(),
+		Status: string(receipt.Status),
+		Reason: receipt.Reason,
+	}
+	if receipt.Status == state.TxStatusMined {
+		message.BlockHash = receipt.BlockHash.Hex()
+		message.Height = receipt.Height
+		message.Index = uint64(receipt.Index)
+	}
+	return message
+}
+
+func toBlockHeaderMessage(header state.BlockHeader) *pb.BlockHeaderMessage {
+	return &pb.BlockHeaderMessage{
+		Parent:     header.Parent.Hex(),
+		Time:       header.Time,
+		Number:     header.Number,
+		Nonce:      header.Nonce,
+		Miner:      header.Miner.Hex(),
+		Pow:        int32(header.PoW),
+		StateRoot:  header.StateRoot.Hex(),
+		TxRoot:     header.TxRoot.Hex(),
+		Seal:       hex.EncodeToString(header.Seal),
+		Difficulty: header.Difficulty,
+		ExtraNonce: header.ExtraNonce,
+	}
+}
+
 func newNodeServer(n *Node) nodeServer {
 	nodeServer := nodeServer{node: n}
 	return nodeServer
@@ -0,0 +1,99 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/driemworks/mercury-blockchain/state"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// the most work packages handed out to external miners that are kept at once
+const maxWorkPackages = 64
+
+// workPackages are the block templates handed out to external miners, by work id
+type workPackages struct {
+	mu     sync.Mutex
+	blocks map[state.Hash]state.Block
+}
+
+func newWorkPackages() *workPackages {
+	return &workPackages{blocks: make(map[state.Hash]state.Block)}
+}
+
+/*
+	Hand out a block template of the pending TXs for an external miner to seal.
+	The work id is the hash of the template, which the miner returns with its nonce.
+*/
+func (n *Node) GetWork() (state.Hash, state.Block, error) {
+	if _, ok := n.state.Engine().(*state.ProofOfWork); !ok {
+		return state.Hash{}, state.Block{}, fmt.Errorf("external mining is only supported with proof of work")
+	}
+	// the template's reward would be minted to the zero address
+	if (n.Coinbase() == common.Address{}) {
+		return state.Hash{}, state.Block{}, fmt.Errorf("no coinbase set, set one before handing out work")
+	}
+	if n.mempool.Len() == 0 && n.emptyBlockInterval <= 0 {
+		return state.Hash{}, state.Block{}, fmt.Errorf("there are no pending TXs to mine")
+	}
+	pendingBlock, err := n.newPendingBlock()
+	if err != nil {
+		return state.Hash{}, state.Block{}, err
+	}
+	block, err := PrepareBlock(n.state.Engine(), n.state, pendingBlock)
+	if err != nil {
+		return state.Hash{}, state.Block{}, err
+	}
+	workID, err := block.Hash()
+	if err != nil {
+		return state.Hash{}, state.Block{}, err
+	}
+
+	n.work.mu.Lock()
+	defer n.work.mu.Unlock()
+	for id, template := range n.work.blocks {
+		// work on a parent that is no longer the tip can't extend the chain
+		if template.Header.Parent != block.Header.Parent || len(n.work.blocks) >= maxWorkPackages {
+			delete(n.work.blocks, id)
+		}
+	}
+	n.work.blocks[workID] = block
+	return workID, block, nil
+}
+
+/*
+	Accept the nonce an external miner found for a work package. The sealed block is
+	validated and added to the chain, then broadcast to peers.
+*/
+func (n *Node) SubmitWork(workID state.Hash, nonce uint32, extraNonce uint32, pow int) (state.Hash, error) {
+	n.work.mu.Lock()
+	block, ok := n.work.blocks[workID]
+	n.work.mu.Unlock()
+	if !ok {
+		return state.Hash{}, fmt.Errorf("unknown work package '%s'", workID.Hex())
+	}
+	if block.Header.Parent != n.state.LatestBlockHash() {
+		return state.Hash{}, fmt.Errorf("work package '%s' is stale", workID.Hex())
+	}
+
+	block.Header.Nonce = nonce
+	block.Header.ExtraNonce = extraNonce
+	block.Header.PoW = pow
+	hash, err := block.Hash()
+	if err != nil {
+		return state.Hash{}, err
+	}
+	if !state.IsBlockHashValid(hash, block.Header.Difficulty) {
+		return state.Hash{}, fmt.Errorf("block hash '%s' doesn't meet the difficulty target", hash.Hex())
+	}
+	if err := n.commitMinedBlock(block); err != nil {
+		return state.Hash{}, err
+	}
+	n.work.mu.Lock()
+	delete(n.work.blocks, workID)
+	n.work.mu.Unlock()
+	logMinedBlock(hash, block, time.Duration(0))
+	return hash, nil
+}
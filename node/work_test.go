@@ -0,0 +1,153 @@
+package node
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/driemworks/mercury-blockchain/mempool"
+	"github.com/driemworks/mercury-blockchain/state"
+	"github.com/driemworks/mercury-blockchain/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// a chain funding the account '%s' with 2 coins
+const testPowGenesis = `{"version": 1, "chain_id": "mercury-test", "consensus": "pow", "difficulty": 1, "block_time": 15, "retarget_interval": 10,
+	"reward": {"initial": 1000000000}, "state": {"%s": {"balance": 200000000}}}`
+
+/*
+	A node on a fresh proof of work chain where every hash meets the target, with one pending tx
+*/
+func newTestWorkNode(t *testing.T) (*Node, func()) {
+	n, _, cleanup := newTestWorkNodeWithKey(t)
+	return n, cleanup
+}
+
+/*
+	A test work node and the key of its funded coinbase account, which signed its pending tx
+*/
+func newTestWorkNodeWithKey(t *testing.T) (*Node, *ecdsa.PrivateKey, func()) {
+	datadir, err := ioutil.TempDir("", "work_test")
+	assert.Nil(t, err)
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+	author := crypto.PubkeyToAddress(key.PublicKey)
+	genesis := fmt.Sprintf(testPowGenesis, author.Hex())
+	assert.Nil(t, os.MkdirAll(filepath.Join(datadir, "manifest"), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(datadir, "manifest", "genesis.json"), []byte(genesis), 0644))
+	s, err := state.NewStateFromDisk(datadir)
+	assert.Nil(t, err)
+
+	n := NewNode("test", datadir, author.Hex(), "127.0.0.1", 8080, false)
+	n.state = s
+	n.mempool = mempool.New(s, mempool.DefaultConfig())
+	tx, err := wallet.SignTx(state.NewTx(author, "topic", 1), n.state.ChainID(), key)
+	assert.Nil(t, err)
+	assert.Nil(t, n.AddPendingTX(tx))
+	// nothing broadcasts mined blocks in the test
+	go func() {
+		for range n.newMinedBlocks {
+		}
+	}()
+	return n, key, func() {
+		s.Close()
+		os.RemoveAll(datadir)
+	}
+}
+
+func TestGetAndSubmitWork(t *testing.T) {
+	n, cleanup := newTestWorkNode(t)
+	defer cleanup()
+
+	workID, template, err := n.GetWork()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), template.Header.Difficulty)
+	assert.Len(t, template.TXs, 2)
+	assert.NotNil(t, template.TXs[0].Coinbase)
+
+	_, err = n.SubmitWork(state.Hash{1}, 42, 0, 1)
+	assert.NotNil(t, err)
+
+	hash, err := n.SubmitWork(workID, 42, 0, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, hash, n.state.LatestBlockHash())
+	block, ok, err := n.state.GetBlock(hash)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(42), block.Header.Nonce)
+	assert.Equal(t, 0, n.mempool.Len())
+	// the 2 funded coins and the reward of 10, less the 1 coin the coinbase paid for its topic
+	assert.Equal(t, 11*state.CoinUnit, n.state.Catalog[n.Coinbase()].Balance)
+
+	// the work package was used up
+	_, err = n.SubmitWork(workID, 42, 0, 1)
+	assert.NotNil(t, err)
+}
+
+func TestGetWorkNeedsCoinbase(t *testing.T) {
+	n, cleanup := newTestWorkNode(t)
+	defer cleanup()
+	n.coinbase = common.Address{}
+
+	_, _, err := n.GetWork()
+	assert.NotNil(t, err)
+}
+
+func TestPrepareBlockWhileAddingBlocks(t *testing.T) {
+	n, cleanup := newTestWorkNode(t)
+	defer cleanup()
+	n.SetEmptyBlockInterval(time.Hour)
+	workID, _, err := n.GetWork()
+	assert.Nil(t, err)
+	first, err := n.SubmitWork(workID, 0, 0, 1)
+	assert.Nil(t, err)
+
+	// the miner prepares blocks, reading headers from the block tree, while blocks are added
+	// to it. Run with -race to check the tree is read under its lock
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			pendingBlock := NewPendingBlock(first, state.Hash{}, 2, n.Coinbase(), nil)
+			_, err := PrepareBlock(n.state.Engine(), n.state, pendingBlock)
+			assert.Nil(t, err)
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		workID, _, err := n.GetWork()
+		assert.Nil(t, err)
+		_, err = n.SubmitWork(workID, 0, 0, 1)
+		assert.Nil(t, err)
+	}
+	<-done
+}
+
+func TestNewPendingBlockWhileAddingBlocks(t *testing.T) {
+	n, cleanup := newTestWorkNode(t)
+	defer cleanup()
+	n.SetEmptyBlockInterval(time.Hour)
+
+	// block templates are built on a copy of the state taken under its lock, run with -race
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_, err := n.newPendingBlock()
+			assert.Nil(t, err)
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		workID, _, err := n.GetWork()
+		assert.Nil(t, err)
+		_, err = n.SubmitWork(workID, 0, 0, 1)
+		assert.Nil(t, err)
+	}
+	<-done
+}
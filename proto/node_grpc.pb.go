@@ -26,8 +26,25 @@ type NodeServiceClient interface {
 	ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (NodeService_ListBlocksClient, error)
 	// add pending transaction
 	AddTransaction(ctx context.Context, in *AddPendingTransactionRequest, opts ...grpc.CallOption) (*AddPendingTransactionResponse, error)
+	// send tokens from the node's account to another account
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	// estimate a fee that gets a tx mined soon, from recent blocks and the pending txs
+	EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error)
 	Subscribe(ctx context.Context, in *JoinChannelRequest, opts ...grpc.CallOption) (NodeService_SubscribeClient, error)
 	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	// get a merkle proof that a tx is included in a block
+	GetTxProof(ctx context.Context, in *TxProofRequest, opts ...grpc.CallOption) (*TxProofResponse, error)
+	// get a tx and whether it is pending, mined or was dropped
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	// get the receipt of a tx: the block it was mined in or why it was dropped
+	GetReceipt(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*ReceiptMessage, error)
+	// vote a proof of authority signer in or out
+	ProposeSigner(ctx context.Context, in *ProposeSignerRequest, opts ...grpc.CallOption) (*ProposeSignerResponse, error)
+	// hand out a block template to an external miner and accept its nonce
+	GetWork(ctx context.Context, in *GetWorkRequest, opts ...grpc.CallOption) (*GetWorkResponse, error)
+	SubmitWork(ctx context.Context, in *SubmitWorkRequest, opts ...grpc.CallOption) (*SubmitWorkResponse, error)
+	// whether the node is mining, its coinbase and hashrate
+	GetMiningStatus(ctx context.Context, in *MiningStatusRequest, opts ...grpc.CallOption) (*MiningStatusResponse, error)
 }
 
 type nodeServiceClient struct {
@@ -88,6 +105,24 @@ func (c *nodeServiceClient) AddTransaction(ctx context.Context, in *AddPendingTr
 	return out, nil
 }
 
+func (c *nodeServiceClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/proto.NodeService/Transfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error) {
+	out := new(EstimateFeeResponse)
+	err := c.cc.Invoke(ctx, "/proto.NodeService/EstimateFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeServiceClient) Subscribe(ctx context.Context, in *JoinChannelRequest, opts ...grpc.CallOption) (NodeService_SubscribeClient, error) {
 	stream, err := c.cc.NewStream(ctx, &NodeService_ServiceDesc.Streams[1], "/proto.NodeService/Subscribe", opts...)
 	if err != nil {
@@ -129,6 +164,69 @@ func (c *nodeServiceClient) Publish(ctx context.Context, in *PublishRequest, opt
 	return out, nil
 }
 
+func (c *nodeServiceClient) GetTxProof(ctx context.Context, in *TxProofRequest, opts ...grpc.CallOption) (*TxProofResponse, error) {
+	out := new(TxProofResponse)
+	err := c.cc.Invoke(ctx, "/proto.NodeService/GetTxProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, "/proto.NodeService/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetReceipt(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*ReceiptMessage, error) {
+	out := new(ReceiptMessage)
+	err := c.cc.Invoke(ctx, "/proto.NodeService/GetReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) ProposeSigner(ctx context.Context, in *ProposeSignerRequest, opts ...grpc.CallOption) (*ProposeSignerResponse, error) {
+	out := new(ProposeSignerResponse)
+	err := c.cc.Invoke(ctx, "/proto.NodeService/ProposeSigner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetWork(ctx context.Context, in *GetWorkRequest, opts ...grpc.CallOption) (*GetWorkResponse, error) {
+	out := new(GetWorkResponse)
+	err := c.cc.Invoke(ctx, "/proto.NodeService/GetWork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) SubmitWork(ctx context.Context, in *SubmitWorkRequest, opts ...grpc.CallOption) (*SubmitWorkResponse, error) {
+	out := new(SubmitWorkResponse)
+	err := c.cc.Invoke(ctx, "/proto.NodeService/SubmitWork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetMiningStatus(ctx context.Context, in *MiningStatusRequest, opts ...grpc.CallOption) (*MiningStatusResponse, error) {
+	out := new(MiningStatusResponse)
+	err := c.cc.Invoke(ctx, "/proto.NodeService/GetMiningStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeServiceServer is the server API for NodeService service.
 // All implementations must embed UnimplementedNodeServiceServer
 // for forward compatibility
@@ -141,8 +239,25 @@ type NodeServiceServer interface {
 	ListBlocks(*ListBlocksRequest, NodeService_ListBlocksServer) error
 	// add pending transaction
 	AddTransaction(context.Context, *AddPendingTransactionRequest) (*AddPendingTransactionResponse, error)
+	// send tokens from the node's account to another account
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	// estimate a fee that gets a tx mined soon, from recent blocks and the pending txs
+	EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error)
 	Subscribe(*JoinChannelRequest, NodeService_SubscribeServer) error
 	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	// get a merkle proof that a tx is included in a block
+	GetTxProof(context.Context, *TxProofRequest) (*TxProofResponse, error)
+	// get a tx and whether it is pending, mined or was dropped
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+	// get the receipt of a tx: the block it was mined in or why it was dropped
+	GetReceipt(context.Context, *GetReceiptRequest) (*ReceiptMessage, error)
+	// vote a proof of authority signer in or out
+	ProposeSigner(context.Context, *ProposeSignerRequest) (*ProposeSignerResponse, error)
+	// hand out a block template to an external miner and accept its nonce
+	GetWork(context.Context, *GetWorkRequest) (*GetWorkResponse, error)
+	SubmitWork(context.Context, *SubmitWorkRequest) (*SubmitWorkResponse, error)
+	// whether the node is mining, its coinbase and hashrate
+	GetMiningStatus(context.Context, *MiningStatusRequest) (*MiningStatusResponse, error)
 	mustEmbedUnimplementedNodeServiceServer()
 }
 
@@ -159,12 +274,39 @@ func (UnimplementedNodeServiceServer) ListBlocks(*ListBlocksRequest, NodeService
 func (UnimplementedNodeServiceServer) AddTransaction(context.Context, *AddPendingTransactionRequest) (*AddPendingTransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddTransaction not implemented")
 }
+func (UnimplementedNodeServiceServer) Transfer(context.Context, *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
+func (UnimplementedNodeServiceServer) EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateFee not implemented")
+}
 func (UnimplementedNodeServiceServer) Subscribe(*JoinChannelRequest, NodeService_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
 func (UnimplementedNodeServiceServer) Publish(context.Context, *PublishRequest) (*PublishResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
 }
+func (UnimplementedNodeServiceServer) GetTxProof(context.Context, *TxProofRequest) (*TxProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxProof not implemented")
+}
+func (UnimplementedNodeServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedNodeServiceServer) GetReceipt(context.Context, *GetReceiptRequest) (*ReceiptMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceipt not implemented")
+}
+func (UnimplementedNodeServiceServer) ProposeSigner(context.Context, *ProposeSignerRequest) (*ProposeSignerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeSigner not implemented")
+}
+func (UnimplementedNodeServiceServer) GetWork(context.Context, *GetWorkRequest) (*GetWorkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWork not implemented")
+}
+func (UnimplementedNodeServiceServer) SubmitWork(context.Context, *SubmitWorkRequest) (*SubmitWorkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitWork not implemented")
+}
+func (UnimplementedNodeServiceServer) GetMiningStatus(context.Context, *MiningStatusRequest) (*MiningStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMiningStatus not implemented")
+}
 func (UnimplementedNodeServiceServer) mustEmbedUnimplementedNodeServiceServer() {}
 
 // UnsafeNodeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -235,6 +377,42 @@ func _NodeService_AddTransaction_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeService/Transfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_EstimateFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).EstimateFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeService/EstimateFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).EstimateFee(ctx, req.(*EstimateFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(JoinChannelRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -274,6 +452,132 @@ func _NodeService_Publish_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_GetTxProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetTxProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeService/GetTxProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetTxProof(ctx, req.(*TxProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeService/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeService/GetReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetReceipt(ctx, req.(*GetReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_ProposeSigner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeSignerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ProposeSigner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeService/ProposeSigner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ProposeSigner(ctx, req.(*ProposeSignerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetWork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeService/GetWork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetWork(ctx, req.(*GetWorkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_SubmitWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitWorkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).SubmitWork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeService/SubmitWork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).SubmitWork(ctx, req.(*SubmitWorkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetMiningStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MiningStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetMiningStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeService/GetMiningStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetMiningStatus(ctx, req.(*MiningStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NodeService_ServiceDesc is the grpc.ServiceDesc for NodeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,10 +593,46 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddTransaction",
 			Handler:    _NodeService_AddTransaction_Handler,
 		},
+		{
+			MethodName: "Transfer",
+			Handler:    _NodeService_Transfer_Handler,
+		},
+		{
+			MethodName: "EstimateFee",
+			Handler:    _NodeService_EstimateFee_Handler,
+		},
 		{
 			MethodName: "Publish",
 			Handler:    _NodeService_Publish_Handler,
 		},
+		{
+			MethodName: "GetTxProof",
+			Handler:    _NodeService_GetTxProof_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _NodeService_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetReceipt",
+			Handler:    _NodeService_GetReceipt_Handler,
+		},
+		{
+			MethodName: "ProposeSigner",
+			Handler:    _NodeService_ProposeSigner_Handler,
+		},
+		{
+			MethodName: "GetWork",
+			Handler:    _NodeService_GetWork_Handler,
+		},
+		{
+			MethodName: "SubmitWork",
+			Handler:    _NodeService_SubmitWork_Handler,
+		},
+		{
+			MethodName: "GetMiningStatus",
+			Handler:    _NodeService_GetMiningStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -308,3 +648,163 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "proto/node.proto",
 }
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	StartMining(ctx context.Context, in *StartMiningRequest, opts ...grpc.CallOption) (*MiningStatusResponse, error)
+	StopMining(ctx context.Context, in *StopMiningRequest, opts ...grpc.CallOption) (*MiningStatusResponse, error)
+	// change the address that receives block rewards, the node's txs are still sent from its account
+	SetCoinbase(ctx context.Context, in *SetCoinbaseRequest, opts ...grpc.CallOption) (*MiningStatusResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) StartMining(ctx context.Context, in *StartMiningRequest, opts ...grpc.CallOption) (*MiningStatusResponse, error) {
+	out := new(MiningStatusResponse)
+	err := c.cc.Invoke(ctx, "/proto.AdminService/StartMining", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) StopMining(ctx context.Context, in *StopMiningRequest, opts ...grpc.CallOption) (*MiningStatusResponse, error) {
+	out := new(MiningStatusResponse)
+	err := c.cc.Invoke(ctx, "/proto.AdminService/StopMining", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetCoinbase(ctx context.Context, in *SetCoinbaseRequest, opts ...grpc.CallOption) (*MiningStatusResponse, error) {
+	out := new(MiningStatusResponse)
+	err := c.cc.Invoke(ctx, "/proto.AdminService/SetCoinbase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility
+type AdminServiceServer interface {
+	StartMining(context.Context, *StartMiningRequest) (*MiningStatusResponse, error)
+	StopMining(context.Context, *StopMiningRequest) (*MiningStatusResponse, error)
+	// change the address that receives block rewards, the node's txs are still sent from its account
+	SetCoinbase(context.Context, *SetCoinbaseRequest) (*MiningStatusResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) StartMining(context.Context, *StartMiningRequest) (*MiningStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartMining not implemented")
+}
+func (UnimplementedAdminServiceServer) StopMining(context.Context, *StopMiningRequest) (*MiningStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopMining not implemented")
+}
+func (UnimplementedAdminServiceServer) SetCoinbase(context.Context, *SetCoinbaseRequest) (*MiningStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCoinbase not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_StartMining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartMiningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StartMining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminService/StartMining",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StartMining(ctx, req.(*StartMiningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_StopMining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopMiningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StopMining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminService/StopMining",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StopMining(ctx, req.(*StopMiningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetCoinbase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCoinbaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetCoinbase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminService/SetCoinbase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetCoinbase(ctx, req.(*SetCoinbaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartMining",
+			Handler:    _AdminService_StartMining_Handler,
+		},
+		{
+			MethodName: "StopMining",
+			Handler:    _AdminService_StopMining_Handler,
+		},
+		{
+			MethodName: "SetCoinbase",
+			Handler:    _AdminService_SetCoinbase_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/node.proto",
+}
@@ -5,7 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -36,12 +36,19 @@ type Block struct {
 }
 
 type BlockHeader struct {
-	Parent Hash           `json:"parent"`
-	Time   uint64         `json:"time"`
-	Number uint64         `json:"number"`
-	Nonce  uint32         `json:"nonce"`
-	Miner  common.Address `json:"miner"`
-	PoW    int            `json:"proof_of_work"`
+	Parent     Hash   `json:"parent"`
+	StateRoot  Hash   `json:"state_root"`
+	TxRoot     Hash   `json:"tx_root"`
+	Time       uint64 `json:"time"`
+	Number     uint64 `json:"number"`
+	Difficulty uint64 `json:"difficulty"`
+	Nonce      uint32 `json:"nonce"`
+	// rolled by the miner when it has tried every nonce
+	ExtraNonce uint32         `json:"extra_nonce,omitempty"`
+	Miner      common.Address `json:"miner"`
+	PoW        int            `json:"proof_of_work"`
+	// the signature of the block's signer under proof of authority
+	Seal []byte `json:"seal,omitempty"`
 }
 
 type BlockFS struct {
@@ -50,7 +57,7 @@ type BlockFS struct {
 }
 
 /*
- Hash the block's transactions
+Hash the block's transactions
 */
 func (b *Block) Hash() (Hash, error) {
 	txJson, err := json.Marshal(b)
@@ -62,19 +69,19 @@ func (b *Block) Hash() (Hash, error) {
 }
 
 /*
-	NewBlock
+NewBlock
 */
-func NewBlock(parent Hash, time uint64, number uint64, txs []SignedTx,
-	nonce uint32, miner common.Address, pow int) Block {
-	return Block{BlockHeader{parent, time, number, nonce, miner, pow}, txs}
+func NewBlock(parent Hash, stateRoot Hash, txRoot Hash, time uint64, number uint64, difficulty uint64,
+	txs []SignedTx, nonce uint32, miner common.Address, pow int) Block {
+	return Block{BlockHeader{parent, stateRoot, txRoot, time, number, difficulty, nonce, 0, miner, pow, nil}, txs}
 }
 
 /*
-	IsBlockHashValid
+IsBlockHashValid compares the hash, as a number, against the target for the difficulty
 */
-func IsBlockHashValid(hash Hash) bool {
-	return fmt.Sprintf("%x", hash[0]) == "0" &&
-		fmt.Sprintf("%x", hash[1]) == "0" &&
-		fmt.Sprintf("%x", hash[2]) == "0" &&
-		fmt.Sprintf("%x", hash[3]) != "0"
+func IsBlockHashValid(hash Hash, difficulty uint64) bool {
+	if hash.IsEmpty() {
+		return false
+	}
+	return new(big.Int).SetBytes(hash[:]).Cmp(DifficultyTarget(difficulty)) <= 0
 }
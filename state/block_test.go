@@ -23,8 +23,11 @@ func Test_UnMarshallText_InValid(t *testing.T) {
 func Test_Hash_Valid(t *testing.T) {
 	block := NewBlock(
 		buildValidHash(),
+		Hash{},
+		Hash{},
 		uint64(time.Now().Nanosecond()),
 		1,
+		DefaultDifficulty,
 		nil,
 		1,
 		NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD"),
@@ -38,16 +41,25 @@ func Test_Hash_Valid(t *testing.T) {
 
 func Test_IsBlockHashValid_EmptyHash(t *testing.T) {
 	invalidHash := Hash{}
-	result := IsBlockHashValid(invalidHash)
+	result := IsBlockHashValid(invalidHash, DefaultDifficulty)
 	assert.False(t, result)
 }
 
 func Test_IsBlockHashValid_ValidHash(t *testing.T) {
 	validHash := buildValidHash()
-	result := IsBlockHashValid(validHash)
+	result := IsBlockHashValid(validHash, DefaultDifficulty)
 	assert.True(t, result)
 }
 
+func Test_IsBlockHashValid_ComparesAgainstTarget(t *testing.T) {
+	hash := buildValidHash()
+	// 0x00000009... is below the target for 2^24 but not for 2^32
+	assert.True(t, IsBlockHashValid(hash, DefaultDifficulty))
+	assert.False(t, IsBlockHashValid(hash, uint64(1)<<32))
+	hash[0] = 0xff
+	assert.True(t, IsBlockHashValid(hash, 1))
+}
+
 func buildValidHash() Hash {
 	validBytes := make([]byte, 32)
 	validBytes[0] = byte(0)
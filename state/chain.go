@@ -0,0 +1,263 @@
+package state
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+// ChainUpdate describes how adding a block changed the canonical chain
+type ChainUpdate struct {
+	// the hash of the added block
+	Hash Hash
+	// blocks that joined the canonical chain, oldest first
+	Added []Block
+	// blocks that left the canonical chain in a reorg, newest first
+	Removed []Block
+}
+
+/*
+ The txs of the removed blocks that are not included in any of the added blocks
+*/
+func (u ChainUpdate) OrphanedTXs() []SignedTx {
+	included := make(map[Hash]bool)
+	for _, block := range u.Added {
+		for _, tx := range block.TXs {
+			if txHash, err := tx.Hash(); err == nil {
+				included[txHash] = true
+			}
+		}
+	}
+	orphaned := make([]SignedTx, 0)
+	for _, block := range u.Removed {
+		for _, tx := range block.TXs {
+			if tx.TxType() == TxTypeCoinbase {
+				// the reward of a removed block is not minted again
+				continue
+			}
+			if txHash, err := tx.Hash(); err == nil && !included[txHash] {
+				orphaned = append(orphaned, tx)
+			}
+		}
+	}
+	return orphaned
+}
+
+// blockUndo holds the account values a block overwrote, nil when the account did not exist
+type blockUndo struct {
+	catalog map[common.Address]*CurrentNodeState
+	nonces  map[common.Address]*uint
+}
+
+func newBlockUndo(before *State, after *State) blockUndo {
+	undo := blockUndo{make(map[common.Address]*CurrentNodeState), make(map[common.Address]*uint)}
+	for account, value := range after.Catalog {
+		prev, ok := before.Catalog[account]
+		if !ok {
+			undo.catalog[account] = nil
+		} else if !reflect.DeepEqual(prev, value) {
+			undo.catalog[account] = &prev
+		}
+	}
+	for account, nonce := range after.Account2Nonce {
+		prev, ok := before.Account2Nonce[account]
+		if !ok {
+			undo.nonces[account] = nil
+		} else if prev != nonce {
+			undo.nonces[account] = &prev
+		}
+	}
+	return undo
+}
+
+func (u blockUndo) revert(s *State) {
+	for account, prev := range u.catalog {
+		if prev == nil {
+			delete(s.Catalog, account)
+		} else {
+			s.Catalog[account] = *prev
+		}
+	}
+	for account, prev := range u.nonces {
+		if prev == nil {
+			delete(s.Account2Nonce, account)
+		} else {
+			s.Account2Nonce[account] = *prev
+		}
+	}
+}
+
+/*
+ Build the block tree from the blocks in the store and make the branch
+ with the most accumulated work the canonical chain
+*/
+func (s *State) loadBlockTree() error {
+	err := s.store.ForEachHeader(func(hash Hash, header BlockHeader) error {
+		if _, ok := s.tree.get(header.Parent); !ok {
+			logrus.Warnf("Ignoring stored block '%x' with unknown parent '%x'\n", hash, header.Parent)
+			return nil
+		}
+		s.tree.add(hash, header, s.engine.Work(header), nil)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	best := s.tree.best()
+	for node := best; !node.hash.IsEmpty(); node = s.tree.nodes[node.header.Parent] {
+		if err := s.store.SetCanonical(node.hash); err != nil {
+			return err
+		}
+	}
+	s.store.TrimCanonical(best.header.Number)
+	s.tree.tip = best
+	return nil
+}
+
+/*
+ Switch the canonical chain to the branch ending in 'newTip'. The state is rolled
+ back to the common ancestor and the blocks of the new branch are applied on top.
+ If any block of the new branch is invalid it is dropped (with its descendants)
+ and the current chain is kept.
+*/
+func (s *State) reorg(newTip *chainNode) (ChainUpdate, error) {
+	ancestor, detached, attached := s.tree.path(s.tree.tip, newTip)
+	logrus.Infof("Reorganising chain: rolling back %d block(s) to block %d and applying %d block(s)\n",
+		len(detached), ancestor.header.Number, len(attached))
+
+	pendingState, err := s.stateAt(ancestor, detached)
+	if err != nil {
+		return ChainUpdate{}, err
+	}
+	undos := make([]blockUndo, len(attached))
+	added := make([]Block, len(attached))
+	for i, node := range attached {
+		block, err := s.blockOf(node)
+		if err != nil {
+			return ChainUpdate{}, err
+		}
+		before := pendingState.copy()
+		if err := ApplyBlock(block, &pendingState); err != nil {
+			s.tree.remove(node.hash)
+			return ChainUpdate{}, fmt.Errorf("rejected branch at block '%x': %s", node.hash, err)
+		}
+		undos[i] = newBlockUndo(&before, &pendingState)
+		pendingState.latestBlock = block
+		pendingState.latestBlockHash = node.hash
+		added[i] = block
+	}
+
+	removed := make([]Block, len(detached))
+	for i, node := range detached {
+		block, err := s.blockOf(node)
+		if err != nil {
+			return ChainUpdate{}, err
+		}
+		removed[i] = block
+		delete(s.undo, node.hash)
+	}
+	for i, node := range attached {
+		if node.block != nil {
+			if _, err := s.store.Put(*node.block); err != nil {
+				return ChainUpdate{}, err
+			}
+			s.tree.persisted(node)
+		}
+		if err := s.store.SetCanonical(node.hash); err != nil {
+			return ChainUpdate{}, err
+		}
+		s.undo[node.hash] = undos[i]
+	}
+	s.store.TrimCanonical(newTip.header.Number)
+	s.tree.tip = newTip
+	s.commit(pendingState)
+
+	return ChainUpdate{newTip.hash, added, removed}, nil
+}
+
+/*
+ The state as of the 'ancestor' block, found by reverting the 'detached' blocks
+ (newest first) from the current state. If a block can't be reverted, the state
+ is rebuilt by replaying the chain from genesis up to the ancestor.
+*/
+func (s *State) stateAt(ancestor *chainNode, detached []*chainNode) (State, error) {
+	pendingState := s.copy()
+	for _, node := range detached {
+		undo, ok := s.undo[node.hash]
+		if !ok {
+			return s.replayTo(ancestor)
+		}
+		undo.revert(&pendingState)
+	}
+	ancestorBlock, err := s.blockOf(ancestor)
+	if err != nil {
+		return State{}, err
+	}
+	pendingState.latestBlock = ancestorBlock
+	pendingState.latestBlockHash = ancestor.hash
+	return pendingState, nil
+}
+
+/*
+ Rebuild the state as of the 'ancestor' block by replaying its chain on top of genesis
+*/
+func (s *State) replayTo(ancestor *chainNode) (State, error) {
+	pendingState := s.copy()
+	pendingState.Catalog = make(map[common.Address]CurrentNodeState)
+	pendingState.Account2Nonce = make(map[common.Address]uint)
+	for account, value := range s.genesis.State {
+		pendingState.Catalog[account] = value
+	}
+	pendingState.latestBlock = Block{}
+	pendingState.latestBlockHash = Hash{}
+
+	chain := make([]*chainNode, 0)
+	for node := ancestor; !node.hash.IsEmpty(); node = s.tree.nodes[node.header.Parent] {
+		chain = append(chain, node)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		block, err := s.blockOf(chain[i])
+		if err != nil {
+			return State{}, err
+		}
+		if err := ApplyBlock(block, &pendingState); err != nil {
+			return State{}, err
+		}
+		pendingState.latestBlock = block
+		pendingState.latestBlockHash = chain[i].hash
+	}
+	return pendingState, nil
+}
+
+/*
+ The block for a node of the block tree, either held in memory or read from the store
+*/
+func (s *State) blockOf(node *chainNode) (Block, error) {
+	if node.hash.IsEmpty() {
+		return Block{}, nil
+	}
+	if node.block != nil {
+		return *node.block, nil
+	}
+	block, ok, err := s.store.Get(node.hash)
+	if err != nil {
+		return Block{}, err
+	}
+	if !ok {
+		return Block{}, fmt.Errorf("block '%x' is missing from the store", node.hash)
+	}
+	return block, nil
+}
+
+/*
+ Replace the state with the pending state
+*/
+func (s *State) commit(pendingState State) {
+	s.Account2Nonce = pendingState.Account2Nonce
+	s.Catalog = pendingState.Catalog
+	s.latestBlockHash = pendingState.latestBlockHash
+	s.latestBlock = pendingState.latestBlock
+	s.hasGenesisBlock = true
+}
@@ -0,0 +1,106 @@
+package state
+
+import (
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BlockTree_BestAndPath(t *testing.T) {
+	tree := newBlockTree()
+	a1 := tree.add(Hash{1}, BlockHeader{Parent: Hash{}, Number: 1, Difficulty: 1}, big.NewInt(1), nil)
+	a2 := tree.add(Hash{2}, BlockHeader{Parent: a1.hash, Number: 2, Difficulty: 1}, big.NewInt(1), nil)
+	tree.tip = a2
+	b2 := tree.add(Hash{3}, BlockHeader{Parent: a1.hash, Number: 2, Difficulty: 1}, big.NewInt(1), nil)
+
+	// equal work keeps the current tip
+	assert.Equal(t, a2, tree.best())
+
+	b3 := tree.add(Hash{4}, BlockHeader{Parent: b2.hash, Number: 3, Difficulty: 1}, big.NewInt(1), nil)
+	assert.Equal(t, b3, tree.best())
+
+	ancestor, detached, attached := tree.path(a2, b3)
+	assert.Equal(t, a1, ancestor)
+	assert.Equal(t, []*chainNode{a2}, detached)
+	assert.Equal(t, []*chainNode{b2, b3}, attached)
+
+	tree.remove(b2.hash)
+	_, ok := tree.get(b3.hash)
+	assert.False(t, ok)
+	assert.Equal(t, a2, tree.best())
+
+	// without a tip to keep, the branch added first wins a tie
+	tree = newBlockTree()
+	first := tree.add(Hash{9}, BlockHeader{Parent: Hash{}, Number: 1, Difficulty: 1}, big.NewInt(1), nil)
+	for i := byte(1); i < 9; i++ {
+		tree.add(Hash{i}, BlockHeader{Parent: Hash{}, Number: 1, Difficulty: 1}, big.NewInt(1), nil)
+	}
+	assert.Equal(t, first, tree.best())
+}
+
+func Test_loadBlockTree_EqualWorkForks(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "chain_test")
+	assert.Nil(t, err)
+	defer RemoveDir(datadir)
+	from, signFn := newTestSigner(t)
+	to := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	assert.Nil(t, InitDataDir(datadir, NewDevGenesis(from)))
+
+	// two forks of block 1 with the same work are both in the store, as after a crash
+	// while the second one was being switched to
+	s, err := NewStateFromDisk(datadir)
+	assert.Nil(t, err)
+	toA := NewTransferTx(from, to, 1, 1)
+	toA.ChainID = s.ChainID()
+	toB := NewTransferTx(from, to, 2, 1)
+	toB.ChainID = s.ChainID()
+	a := buildTestBlock(t, s, from, signTestTx(t, toA, signFn))
+	b := buildTestBlock(t, s, from, signTestTx(t, toB, signFn))
+	_, err = s.AddBlock(a)
+	assert.Nil(t, err)
+	aHash, err := a.Hash()
+	assert.Nil(t, err)
+	_, err = s.store.Put(b)
+	assert.Nil(t, err)
+	s.Close()
+
+	// every restart picks the fork that was stored first
+	for i := 0; i < 10; i++ {
+		s, err = NewStateFromDisk(datadir)
+		assert.Nil(t, err)
+		assert.Equal(t, aHash, s.LatestBlockHash())
+		assert.Equal(t, uint64(1), s.Catalog[to].Balance)
+		s.Close()
+	}
+}
+
+func Test_BlockUndo_Revert(t *testing.T) {
+	existing := NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	created := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	before := &State{
+		Catalog:       map[common.Address]CurrentNodeState{existing: {Balance: 10}},
+		Account2Nonce: map[common.Address]uint{existing: 1},
+	}
+	after := before.copy()
+	after.Catalog[existing] = CurrentNodeState{Balance: 9}
+	after.Catalog[created] = CurrentNodeState{Balance: 10}
+	after.Account2Nonce[existing] = 2
+
+	newBlockUndo(before, &after).revert(&after)
+	assert.Equal(t, before.Catalog, after.Catalog)
+	assert.Equal(t, before.Account2Nonce, after.Account2Nonce)
+}
+
+func Test_ChainUpdate_OrphanedTXs(t *testing.T) {
+	author := NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	shared := NewSignedTx(NewTx(author, "shared", 1), nil)
+	orphan := NewSignedTx(NewTx(author, "orphan", 2), nil)
+	update := ChainUpdate{
+		Added:   []Block{{TXs: []SignedTx{shared}}},
+		Removed: []Block{{TXs: []SignedTx{shared, orphan}}},
+	}
+	assert.Equal(t, []SignedTx{orphan}, update.OrphanedTXs())
+}
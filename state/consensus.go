@@ -0,0 +1,92 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	ConsensusProofOfWork      = "pow"
+	ConsensusProofOfAuthority = "poa"
+	ConsensusDev              = "dev"
+)
+
+// a block's time may be at most this far ahead of the local clock
+const maxFutureBlockTime = 15 * time.Second
+
+// ChainReader gives a consensus engine access to known blocks
+type ChainReader interface {
+	// GetHeader returns the header of the block with the given hash.
+	// The empty hash is the parent of the first block and has an empty header.
+	GetHeader(hash Hash) (BlockHeader, bool)
+	// GetBlock returns the block with the given hash, on any branch
+	GetBlock(hash Hash) (Block, bool, error)
+}
+
+// Engine implements the consensus rules that decide who may produce a block and how it is sealed
+type Engine interface {
+	// Prepare sets the consensus fields of a new block's header (e.g. its difficulty)
+	Prepare(chain ChainReader, header *BlockHeader) error
+	// Seal produces the sealed version of the block, blocking until it is sealed or the ctx is cancelled
+	Seal(ctx context.Context, block Block) (Block, error)
+	// VerifyHeader checks the consensus fields and seal of a block's header
+	VerifyHeader(chain ChainReader, header BlockHeader, hash Hash) error
+	// Work is the weight the block adds to its branch when choosing the canonical chain
+	Work(header BlockHeader) *big.Int
+}
+
+/*
+ Build the consensus engine selected in the genesis
+*/
+func NewEngine(genesis Genesis) (Engine, error) {
+	switch genesis.Consensus {
+	case "", ConsensusProofOfWork:
+		return NewProofOfWork(genesis), nil
+	case ConsensusProofOfAuthority:
+		if len(genesis.Signers) == 0 {
+			return nil, fmt.Errorf("proof of authority needs at least one signer in the genesis")
+		}
+		return NewProofOfAuthority(genesis), nil
+	case ConsensusDev:
+		return NewInstantSeal(), nil
+	default:
+		return nil, fmt.Errorf("unknown consensus engine '%s'", genesis.Consensus)
+	}
+}
+
+/*
+ Check the block's time isn't further ahead of the local clock than maxFutureBlockTime
+*/
+func verifyNotInFuture(header BlockHeader) error {
+	if limit := time.Now().Add(maxFutureBlockTime).Unix(); int64(header.Time) > limit {
+		return fmt.Errorf("block time %d is more than %s ahead of the local clock", header.Time, maxFutureBlockTime)
+	}
+	return nil
+}
+
+/*
+ The header of the block with the given hash from the block tree
+*/
+func (s *State) GetHeader(hash Hash) (BlockHeader, bool) {
+	node, ok := s.tree.get(hash)
+	if !ok {
+		return BlockHeader{}, false
+	}
+	return node.header, true
+}
+
+/*
+ The ID of the chain, txs must be signed for it
+*/
+func (s *State) ChainID() string {
+	return s.genesis.ChainID
+}
+
+/*
+ The consensus engine of the chain
+*/
+func (s *State) Engine() Engine {
+	return s.engine
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 //
@@ -28,6 +29,10 @@ func getGenesisJsonFilePath(datadir string) string {
 	return filepath.Join(getDatabaseDirPath(datadir), "genesis.json")
 }
 
+func getGenesisHashFilePath(datadir string) string {
+	return filepath.Join(getDatabaseDirPath(datadir), "genesis.hash")
+}
+
 func getBlocksDbFilePath(datadir string, isTemp bool) string {
 	if isTemp {
 		return filepath.Join(getDatabaseDirPath(datadir), "block.db.tmp")
@@ -35,24 +40,22 @@ func getBlocksDbFilePath(datadir string, isTemp bool) string {
 	return filepath.Join(getDatabaseDirPath(datadir), "block.db")
 }
 
+func GetMempoolJournalFilePath(datadir string) string {
+	return filepath.Join(getDatabaseDirPath(datadir), "mempool.journal")
+}
+
+func getReceiptsDbFilePath(datadir string) string {
+	return filepath.Join(getDatabaseDirPath(datadir), "receipts.db")
+}
+
 func GetEncryptionKeysFilePath(datadir string) string {
 	return filepath.Join(GetKeystoreDirPath(datadir), "keys.json")
 }
 
 func initDataDirIfNotExists(dataDir string) error {
-	// if the genesis.json file dne, create it
+	// the genesis is never made up here, the datadir must be initialised with one first
 	if !fileExists(getGenesisJsonFilePath(dataDir)) {
-		// create root directory for our db (create parents dirs if needed)
-		// ensure root dir exists
-		if !fileExists(getDatabaseDirPath(dataDir)) {
-			if err := os.MkdirAll(getDatabaseDirPath(dataDir), os.ModePerm); err != nil {
-				return err
-			}
-		}
-		// write genesis.json
-		if err := writeGenesisToDisk(getGenesisJsonFilePath(dataDir)); err != nil {
-			return err
-		}
+		return fmt.Errorf("'%s' has no genesis, initialise it with 'mercury init'", dataDir)
 	}
 
 	if !fileExists(getBlocksDbFilePath(dataDir, false)) {
@@ -69,6 +72,55 @@ func initDataDirIfNotExists(dataDir string) error {
 	return nil
 }
 
+/*
+ Initialise the datadir with the genesis of a new chain. Initialising it again with
+ the same genesis does nothing, a datadir with another genesis is left alone.
+*/
+func InitDataDir(dataDir string, genesis Genesis) error {
+	if err := genesis.Validate(); err != nil {
+		return err
+	}
+	genesisJSON, err := json.MarshalIndent(genesis, "", "    ")
+	if err != nil {
+		return err
+	}
+	if fileExists(getGenesisJsonFilePath(dataDir)) {
+		existing, err := loadGenesis(getGenesisJsonFilePath(dataDir))
+		if err != nil {
+			return err
+		}
+		existingHash, err := existing.Hash()
+		if err != nil {
+			return err
+		}
+		hash, err := genesis.Hash()
+		if err != nil {
+			return err
+		}
+		if existingHash != hash {
+			return fmt.Errorf("'%s' is already initialised with another genesis", dataDir)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(getDatabaseDirPath(dataDir), os.ModePerm); err != nil {
+		return err
+	}
+	if err := writeFileSync(getGenesisJsonFilePath(dataDir), genesisJSON); err != nil {
+		return err
+	}
+	return syncDir(getDatabaseDirPath(dataDir))
+}
+
+/*
+ Write a dev genesis funding 'account' to the datadir, unless it already has a genesis
+*/
+func InitDevDataDir(dataDir string, account common.Address) error {
+	if fileExists(getGenesisJsonFilePath(dataDir)) {
+		return nil
+	}
+	return InitDataDir(dataDir, NewDevGenesis(account))
+}
+
 func WriteEncryptionKeys(datadir string, key keystore.CryptoJSON) error {
 	if !fileExists(GetEncryptionKeysFilePath(datadir)) {
 		_json, err := json.Marshal(key)
@@ -84,6 +136,39 @@ func writeEmptyFileToDisk(path string) error {
 	return ioutil.WriteFile(path, []byte(""), os.ModePerm)
 }
 
+func getSnapshotFilePath(datadir string, isTemp bool) string {
+	if isTemp {
+		return filepath.Join(getDatabaseDirPath(datadir), "snapshot.json.tmp")
+	}
+	return filepath.Join(getDatabaseDirPath(datadir), "snapshot.json")
+}
+
+// write the file and flush it to disk before returning
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
 func fileExists(filepath string) bool {
 	if _, err := os.Stat(filepath); err != nil && os.IsNotExist(err) {
 		return false
@@ -139,7 +224,3 @@ func Rename(originalPath string, destinationPath string) error {
 func RemoveDir(path string) error {
 	return os.RemoveAll(path)
 }
-
-func writeGenesisToDisk(path string) error {
-	return ioutil.WriteFile(path, []byte(genesisJson), 0644)
-}
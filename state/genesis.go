@@ -1,29 +1,226 @@
 package state
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
 )
 
+// the version of the genesis format. Version 1 holds amounts in base units rather than coins.
+const GenesisVersion = 1
+
+// the version of the encoding the genesis hash is computed over
+const genesisHashVersion = 1
+
+// the genesis of the Mercury test network, the genesis 'mercury init' uses by default
 var genesisJson = `
 {
-    "genesis_time": "2021-02-012T00:00:00.000000000Z",
+    "version": 1,
+    "genesis_time": "2021-02-12T00:00:00Z",
     "chain_id": "driemworks-blockchain",
+    "consensus": "pow",
+    "difficulty": 16777216,
+    "block_time": 15,
+    "retarget_interval": 10,
+    "reward": {
+        "initial": 1000000000,
+        "halving_interval": 210000,
+        "max_supply": 20000000000000000
+    },
     "state": {
         "0x96131b31b9935f6388502b502cf544c1a8c65ad6": {
 			"alias": "tony",
             "sent": [],
             "inbox": [],
-			"balance": 100000000,
-			"pending_balance": 100000000
+			"balance": 10000000000000000
         }
     }
 }`
 
 type Genesis struct {
-	State map[common.Address]CurrentNodeState `json:"state"`
+	// the version of the genesis format, genesis files without one hold amounts in coins
+	Version uint64 `json:"version"`
+	// txs are signed for this chain, txs signed for a chain with another ID are rejected
+	ChainID string `json:"chain_id"`
+	// blocks up to this height may hold txs without a chain ID, signed before txs were signed for a chain
+	ChainIDFork uint64 `json:"chain_id_fork,omitempty"`
+	// when the chain was created, RFC 3339
+	GenesisTime string                              `json:"genesis_time,omitempty"`
+	State       map[common.Address]CurrentNodeState `json:"state"`
+	// the consensus engine of the chain, proof of work by default
+	Consensus string `json:"consensus"`
+	// the difficulty of the first block
+	Difficulty uint64 `json:"difficulty"`
+	// target seconds between blocks
+	BlockTime uint64 `json:"block_time"`
+	// number of blocks between difficulty adjustments
+	RetargetInterval uint64 `json:"retarget_interval"`
+	// the addresses allowed to seal blocks under proof of authority
+	Signers []common.Address `json:"signers,omitempty"`
+	// the block rewards paid out by coinbase txs. Without a schedule the
+	// miner is credited BlockReward implicitly, as on chains created before coinbase txs
+	Reward *RewardSchedule `json:"reward,omitempty"`
+}
+
+// the balance of the pre-funded account in a dev genesis
+const DevBalance = 100000000 * CoinUnit
+
+// the chain ID of a dev genesis
+const DevChainID = "mercury-dev"
+
+/*
+ A genesis for local development that seals blocks instantly and funds 'account'
+*/
+func NewDevGenesis(account common.Address) Genesis {
+	return Genesis{
+		Version: GenesisVersion,
+		ChainID: DevChainID,
+		State: map[common.Address]CurrentNodeState{
+			account: {Balance: DevBalance},
+		},
+		Consensus:        ConsensusDev,
+		Difficulty:       devDifficulty,
+		BlockTime:        DefaultBlockTime,
+		RetargetInterval: DefaultRetargetInterval,
+		Reward:           &RewardSchedule{Initial: BlockReward},
+	}
+}
+
+/*
+ The genesis of the Mercury test network
+*/
+func DefaultGenesis() (Genesis, error) {
+	var genesis Genesis
+	if err := json.Unmarshal([]byte(genesisJson), &genesis); err != nil {
+		return Genesis{}, err
+	}
+	return genesis, nil
+}
+
+/*
+ Read a genesis file, e.g. one made with 'mercury genesis new', and check it can start a chain
+*/
+func ReadGenesisFile(path string) (Genesis, error) {
+	genesis, err := loadGenesis(path)
+	if err != nil {
+		return Genesis{}, err
+	}
+	return genesis, genesis.Validate()
+}
+
+/*
+ Check the genesis is complete and consistent before a chain is started from it
+*/
+func (g Genesis) Validate() error {
+	if g.Version != GenesisVersion {
+		return fmt.Errorf("genesis version must be '%d' not '%d'", GenesisVersion, g.Version)
+	}
+	if strings.TrimSpace(g.ChainID) == "" {
+		return fmt.Errorf("genesis must have a chain_id")
+	}
+	if g.GenesisTime != "" {
+		if _, err := time.Parse(time.RFC3339, g.GenesisTime); err != nil {
+			return fmt.Errorf("invalid genesis_time '%s'. %s", g.GenesisTime, err.Error())
+		}
+	}
+	if _, err := NewEngine(g); err != nil {
+		return err
+	}
+	if g.Reward != nil && g.Reward.MaxSupply != 0 && g.Supply() > g.Reward.MaxSupply {
+		return fmt.Errorf("genesis allocates %d base units, more than the max supply of %d",
+			g.Supply(), g.Reward.MaxSupply)
+	}
+	return nil
+}
+
+/*
+ The hash of the genesis, which identifies the chain started from it. Fields left out
+ of the genesis hash the same as their defaults.
+ The hash covers a fixed list of fields in a fixed encoding, rather than the JSON of the
+ struct, so adding a field to Genesis doesn't change the hash of existing chains. A new
+ field that changes the rules of a chain must be added to the list along with a new
+ genesisHashVersion.
+*/
+func (g Genesis) Hash() (Hash, error) {
+	g = g.withDefaults()
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "mercury genesis v%d\n", genesisHashVersion)
+	fmt.Fprintf(&buf, "version=%d\n", g.Version)
+	fmt.Fprintf(&buf, "chain_id=%q\n", g.ChainID)
+	fmt.Fprintf(&buf, "chain_id_fork=%d\n", g.ChainIDFork)
+	fmt.Fprintf(&buf, "genesis_time=%q\n", g.GenesisTime)
+	fmt.Fprintf(&buf, "consensus=%q\n", g.Consensus)
+	fmt.Fprintf(&buf, "difficulty=%d\n", g.Difficulty)
+	fmt.Fprintf(&buf, "block_time=%d\n", g.BlockTime)
+	fmt.Fprintf(&buf, "retarget_interval=%d\n", g.RetargetInterval)
+	for _, signer := range g.Signers {
+		fmt.Fprintf(&buf, "signer=%s\n", signer.Hex())
+	}
+	if g.Reward != nil {
+		fmt.Fprintf(&buf, "reward=%d,%d,%d\n", g.Reward.Initial, g.Reward.HalvingInterval, g.Reward.MaxSupply)
+	}
+	accounts := make([]common.Address, 0, len(g.State))
+	for account := range g.State {
+		accounts = append(accounts, account)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i][:], accounts[j][:]) < 0
+	})
+	for _, account := range accounts {
+		fmt.Fprintf(&buf, "account=%s,%d", account.Hex(), g.State[account].Balance)
+		for _, channel := range g.State[account].OwnedChannels {
+			fmt.Fprintf(&buf, ",%x", channel)
+		}
+		buf.WriteByte('\n')
+	}
+	return sha256.Sum256(buf.Bytes()), nil
+}
+
+func writeGenesisHash(path string, hash Hash) error {
+	hashText, err := hash.MarshalText()
+	if err != nil {
+		return err
+	}
+	return writeFileSync(path, hashText)
+}
+
+/*
+ Check the genesis is the one the data in the datadir was created with. The hash of the
+ genesis is written the first time the node starts and every later start must match it.
+*/
+func checkGenesisHash(datadir string, genesis Genesis) error {
+	hash, err := genesis.Hash()
+	if err != nil {
+		return err
+	}
+	path := getGenesisHashFilePath(datadir)
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return writeGenesisHash(path, hash)
+	}
+	if err != nil {
+		return err
+	}
+	var persisted Hash
+	if err := persisted.UnmarshalText(bytes.TrimSpace(content)); err != nil {
+		return fmt.Errorf("invalid genesis hash in '%s'. %s", path, err.Error())
+	}
+	if persisted != hash {
+		return fmt.Errorf("the genesis in '%s' has hash %s but the data in it was created with genesis %s",
+			datadir, hash.Hex(), persisted.Hex())
+	}
+	return nil
 }
 
 func loadGenesis(filepath string) (Genesis, error) {
@@ -37,5 +234,113 @@ func loadGenesis(filepath string) (Genesis, error) {
 	if err != nil {
 		return Genesis{}, err
 	}
-	return loadedGenesis, nil
+	if loadedGenesis.Version != GenesisVersion {
+		return Genesis{}, fmt.Errorf("genesis version must be '%d' not '%d'", GenesisVersion, loadedGenesis.Version)
+	}
+	return loadedGenesis.withDefaults(), nil
+}
+
+/*
+ The genesis with the defaults of the fields it leaves out
+*/
+func (g Genesis) withDefaults() Genesis {
+	// genesis files written before difficulty retargeting keep the original proof of work
+	if g.Difficulty == 0 {
+		g.Difficulty = DefaultDifficulty
+	}
+	if g.BlockTime == 0 {
+		g.BlockTime = DefaultBlockTime
+	}
+	if g.RetargetInterval == 0 {
+		g.RetargetInterval = DefaultRetargetInterval
+	}
+	return g
+}
+
+/*
+ Migrate a genesis file written before amounts were integers in base units.
+ Balances and reward amounts are converted from coins to base units and the original
+ file is kept next to it as genesis.json.bak. Genesis files that are already current are left alone.
+*/
+func migrateGenesis(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var genesis map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&genesis); err != nil {
+		return err
+	}
+	if version, ok := genesis["version"]; ok {
+		if n, ok := version.(json.Number); !ok || n.String() != "0" {
+			return nil
+		}
+	}
+
+	if accounts, ok := genesis["state"].(map[string]interface{}); ok {
+		for address, account := range accounts {
+			fields, ok := account.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("genesis account '%s' is not an object", address)
+			}
+			if err := coinsToBaseUnits(fields, "balance"); err != nil {
+				return fmt.Errorf("genesis account '%s': %s", address, err)
+			}
+		}
+	}
+	if reward, ok := genesis["reward"].(map[string]interface{}); ok {
+		for _, field := range []string{"initial", "max_supply"} {
+			if err := coinsToBaseUnits(reward, field); err != nil {
+				return fmt.Errorf("genesis reward: %s", err)
+			}
+		}
+	}
+	genesis["version"] = GenesisVersion
+
+	migrated, err := json.MarshalIndent(genesis, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileSync(path+".bak", content); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := writeFileSync(tmpPath, migrated); err != nil {
+		return err
+	}
+	if err := Rename(tmpPath, path); err != nil {
+		return err
+	}
+	if err := syncDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	logrus.Infof("Migrated '%s' to base unit amounts, the original is kept in '%s.bak'\n", path, path)
+	return nil
+}
+
+/*
+ Convert the amount in coins under 'field' to base units, rounding down to a whole base unit
+*/
+func coinsToBaseUnits(fields map[string]interface{}, field string) error {
+	value, ok := fields[field]
+	if !ok {
+		return nil
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return fmt.Errorf("'%s' is not a number", field)
+	}
+	coins, ok := new(big.Rat).SetString(number.String())
+	if !ok || coins.Sign() < 0 {
+		return fmt.Errorf("'%s' must be a non-negative number not '%s'", field, number)
+	}
+	units := coins.Mul(coins, new(big.Rat).SetInt(new(big.Int).SetUint64(CoinUnit)))
+	whole := new(big.Int).Quo(units.Num(), units.Denom())
+	if !whole.IsUint64() {
+		return fmt.Errorf("'%s' of %s coins is too large", field, number)
+	}
+	fields[field] = whole.Uint64()
+	return nil
 }
@@ -1,6 +1,10 @@
 package state
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,3 +16,135 @@ func Test_loadGenesis_Success(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, genesis)
 }
+
+func Test_InitDevDataDir(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "genesis_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(datadir)
+	account := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+
+	assert.Nil(t, InitDevDataDir(datadir, account))
+	s, err := NewStateFromDisk(datadir)
+	assert.Nil(t, err)
+	defer s.Close()
+	assert.IsType(t, &InstantSeal{}, s.Engine())
+	assert.Equal(t, uint64(DevBalance), s.Catalog[account].Balance)
+
+	// an existing genesis is kept
+	assert.Nil(t, InitDevDataDir(datadir, NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")))
+	genesis, err := loadGenesis(getGenesisJsonFilePath(datadir))
+	assert.Nil(t, err)
+	assert.Contains(t, genesis.State, account)
+}
+
+func Test_migrateGenesis(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "genesis_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(datadir)
+	path := filepath.Join(datadir, "genesis.json")
+	legacy := `{"consensus": "pow", "reward": {"initial": 10, "halving_interval": 210000, "max_supply": 200000000},
+		"state": {"0x96131b31b9935f6388502b502cf544c1a8c65ad6": {"balance": 1.5}}}`
+	assert.Nil(t, ioutil.WriteFile(path, []byte(legacy), 0644))
+
+	// a genesis without a version can't be loaded until it is migrated
+	_, err = loadGenesis(path)
+	assert.NotNil(t, err)
+
+	assert.Nil(t, migrateGenesis(path))
+	genesis, err := loadGenesis(path)
+	assert.Nil(t, err)
+	account := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	assert.Equal(t, uint64(GenesisVersion), genesis.Version)
+	assert.Equal(t, 3*CoinUnit/2, genesis.State[account].Balance)
+	assert.Equal(t, BlockReward, genesis.Reward.Initial)
+	assert.Equal(t, uint64(210000), genesis.Reward.HalvingInterval)
+	assert.Equal(t, 200000000*CoinUnit, genesis.Reward.MaxSupply)
+	backup, err := ioutil.ReadFile(path + ".bak")
+	assert.Nil(t, err)
+	assert.Equal(t, legacy, string(backup))
+
+	// migrating again changes nothing
+	migrated, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Nil(t, migrateGenesis(path))
+	again, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, migrated, again)
+}
+
+func Test_InitDataDir(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "genesis_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(datadir)
+	account := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	genesis := NewDevGenesis(account)
+
+	// the node won't start before the datadir is initialised
+	_, err = NewStateFromDisk(datadir)
+	assert.NotNil(t, err)
+
+	assert.Nil(t, InitDataDir(datadir, genesis))
+	s, err := NewStateFromDisk(datadir)
+	assert.Nil(t, err)
+	s.Close()
+	hash, err := genesis.Hash()
+	assert.Nil(t, err)
+	persisted, err := ioutil.ReadFile(getGenesisHashFilePath(datadir))
+	assert.Nil(t, err)
+	assert.Equal(t, hash.Hex(), string(persisted))
+
+	// initialising again with the same genesis is fine, another genesis is refused
+	assert.Nil(t, InitDataDir(datadir, genesis))
+	other := NewDevGenesis(account)
+	other.ChainID = "other-chain"
+	assert.NotNil(t, InitDataDir(datadir, other))
+
+	// the node refuses to start once the genesis no longer matches its data
+	otherJSON, err := json.Marshal(other)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(getGenesisJsonFilePath(datadir), otherJSON, 0644))
+	_, err = NewStateFromDisk(datadir)
+	assert.NotNil(t, err)
+}
+
+func Test_Genesis_Validate(t *testing.T) {
+	genesis, err := DefaultGenesis()
+	assert.Nil(t, err)
+	assert.Nil(t, genesis.Validate())
+
+	noChainID := genesis
+	noChainID.ChainID = ""
+	assert.NotNil(t, noChainID.Validate())
+
+	badTime := genesis
+	badTime.GenesisTime = "yesterday"
+	assert.NotNil(t, badTime.Validate())
+
+	noSigners := genesis
+	noSigners.Consensus = ConsensusProofOfAuthority
+	assert.NotNil(t, noSigners.Validate())
+
+	overSupply := genesis
+	overSupply.Reward = &RewardSchedule{Initial: BlockReward, MaxSupply: CoinUnit}
+	assert.NotNil(t, overSupply.Validate())
+}
+
+func Test_Genesis_Hash(t *testing.T) {
+	// the hash of the test network genesis must never change, it identifies the chain
+	genesis, err := DefaultGenesis()
+	assert.Nil(t, err)
+	hash, err := genesis.Hash()
+	assert.Nil(t, err)
+	assert.Equal(t, "94ee287b193c49c815364fbea6cfd32728ebbea572796bb18a5c9e80a3c3d389", hash.Hex())
+
+	// a chain without rewards isn't the chain that credits miners implicitly
+	noReward := genesis
+	noReward.Reward = &RewardSchedule{}
+	legacyReward := genesis
+	legacyReward.Reward = nil
+	noRewardHash, err := noReward.Hash()
+	assert.Nil(t, err)
+	legacyRewardHash, err := legacyReward.Hash()
+	assert.Nil(t, err)
+	assert.NotEqual(t, noRewardHash, legacyRewardHash)
+}
@@ -0,0 +1,41 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// the difficulty of every block sealed in dev mode
+const devDifficulty = uint64(1)
+
+// InstantSeal is the dev mode engine: blocks have a trivial difficulty and are sealed
+// as soon as they are built, so local chains don't wait on proof of work
+type InstantSeal struct{}
+
+func NewInstantSeal() *InstantSeal {
+	return &InstantSeal{}
+}
+
+func (e *InstantSeal) Prepare(chain ChainReader, header *BlockHeader) error {
+	header.Difficulty = devDifficulty
+	return nil
+}
+
+func (e *InstantSeal) Seal(ctx context.Context, block Block) (Block, error) {
+	if err := ctx.Err(); err != nil {
+		return Block{}, fmt.Errorf("sealing cancelled. %s", err)
+	}
+	return block, nil
+}
+
+func (e *InstantSeal) VerifyHeader(chain ChainReader, header BlockHeader, hash Hash) error {
+	if header.Difficulty != devDifficulty {
+		return fmt.Errorf("block difficulty must be '%d' not '%d'", devDifficulty, header.Difficulty)
+	}
+	return nil
+}
+
+func (e *InstantSeal) Work(header BlockHeader) *big.Int {
+	return new(big.Int).SetUint64(header.Difficulty)
+}
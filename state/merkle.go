@@ -0,0 +1,107 @@
+package state
+
+import (
+	"crypto/sha256"
+)
+
+// leaves and inner nodes are hashed with different prefixes, so an inner node
+// can't be passed off as a leaf
+const (
+	merkleLeafPrefix = 0x00
+	merkleNodePrefix = 0x01
+)
+
+/*
+ Compute the root of a binary Merkle tree over the given leaves.
+ If a level has an odd number of nodes the last node moves up to the next level
+ as it is, it isn't paired with itself: a tree ending in a duplicated leaf would
+ have the same root as the tree without it.
+ The root of an empty tree is the empty hash.
+*/
+func MerkleRoot(leaves []Hash) Hash {
+	if len(leaves) == 0 {
+		return Hash{}
+	}
+	level := merkleLeaves(leaves)
+	for len(level) > 1 {
+		level = nextMerkleLevel(level)
+	}
+	return level[0]
+}
+
+func merkleLeaves(leaves []Hash) []Hash {
+	level := make([]Hash, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = hashMerkleLeaf(leaf)
+	}
+	return level
+}
+
+func nextMerkleLevel(level []Hash) []Hash {
+	next := make([]Hash, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			next = append(next, level[i])
+			break
+		}
+		next = append(next, hashMerkleNode(level[i], level[i+1]))
+	}
+	return next
+}
+
+func hashMerkleLeaf(leaf Hash) Hash {
+	return sha256.Sum256(append([]byte{merkleLeafPrefix}, leaf[:]...))
+}
+
+func hashMerkleNode(left Hash, right Hash) Hash {
+	data := append([]byte{merkleNodePrefix}, left[:]...)
+	return sha256.Sum256(append(data, right[:]...))
+}
+
+/*
+ Build the proof that the leaf at 'index' is part of the tree: the sibling of the
+ node on the path from the leaf to the root, at each level of the tree where it has one
+*/
+func MerkleProof(leaves []Hash, index int) []Hash {
+	if index < 0 || index >= len(leaves) {
+		return nil
+	}
+	siblings := make([]Hash, 0)
+	level := merkleLeaves(leaves)
+	for len(level) > 1 {
+		if sibling := index ^ 1; sibling < len(level) {
+			siblings = append(siblings, level[sibling])
+		}
+		level = nextMerkleLevel(level)
+		index /= 2
+	}
+	return siblings
+}
+
+/*
+ Verify that 'leaf' is the leaf at 'index' of the tree of 'count' leaves with the given root
+*/
+func VerifyMerkleProof(root Hash, leaf Hash, index int, count int, siblings []Hash) bool {
+	if index < 0 || index >= count {
+		return false
+	}
+	node := hashMerkleLeaf(leaf)
+	for ; count > 1; count = (count + 1) / 2 {
+		// the last node of an odd level has no sibling
+		if index == count-1 && index%2 == 0 {
+			index /= 2
+			continue
+		}
+		if len(siblings) == 0 {
+			return false
+		}
+		if index%2 == 0 {
+			node = hashMerkleNode(node, siblings[0])
+		} else {
+			node = hashMerkleNode(siblings[0], node)
+		}
+		siblings = siblings[1:]
+		index /= 2
+	}
+	return len(siblings) == 0 && node == root
+}
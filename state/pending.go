@@ -0,0 +1,88 @@
+package state
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PendingState is the committed state with the effects of the pending txs on top.
+// It only tracks the balances and nonces the pending txs change and never modifies the committed state.
+type PendingState struct {
+	mu       sync.Mutex
+	base     *State
+	balances map[common.Address]uint64
+	nonces   map[common.Address]uint
+}
+
+/*
+ A pending view of the state without any pending txs
+*/
+func (s *State) NewPendingState() *PendingState {
+	return &PendingState{
+		base:     s,
+		balances: make(map[common.Address]uint64),
+		nonces:   make(map[common.Address]uint),
+	}
+}
+
+/*
+ The balance of the account once its pending txs are mined
+*/
+func (p *PendingState) Balance(account common.Address) uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.balance(account)
+}
+
+/*
+ The nonce of the account's latest tx, pending or mined
+*/
+func (p *PendingState) Nonce(account common.Address) uint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.nonce(account)
+}
+
+/*
+ Add the effects of a pending tx. The tx is rejected if its author can't cover its cost
+ after the txs already pending.
+*/
+func (p *PendingState) ApplyTx(tx SignedTx) error {
+	if err := tx.ValidatePayload(); err != nil {
+		return err
+	}
+	if tx.TxType() == TxTypeCoinbase {
+		return fmt.Errorf("coinbase txs are only created by miners")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	balance := p.balance(tx.Author)
+	if balance < tx.Cost() {
+		return fmt.Errorf("insufficient balance. '%s' has %d pending but the tx costs %d",
+			tx.Author.Hex(), balance, tx.Cost())
+	}
+	p.balances[tx.Author] = balance - tx.Cost()
+	if tx.TxType() == TxTypeTransfer {
+		p.balances[tx.Transfer.To] = p.balance(tx.Transfer.To) + tx.Transfer.Amount
+	}
+	if tx.Nonce > p.nonce(tx.Author) {
+		p.nonces[tx.Author] = tx.Nonce
+	}
+	return nil
+}
+
+func (p *PendingState) balance(account common.Address) uint64 {
+	if balance, ok := p.balances[account]; ok {
+		return balance
+	}
+	return p.base.Account(account).Balance
+}
+
+func (p *PendingState) nonce(account common.Address) uint {
+	if nonce, ok := p.nonces[account]; ok {
+		return nonce
+	}
+	return p.base.Nonce(account)
+}
@@ -0,0 +1,35 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PendingState_ApplyTx(t *testing.T) {
+	from := NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	to := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	s := NewMemoryState(map[common.Address]CurrentNodeState{from: {Balance: 3 * CoinUnit}}, map[common.Address]uint{from: 4})
+	pending := s.NewPendingState()
+	assert.Equal(t, uint(4), pending.Nonce(from))
+
+	assert.Nil(t, pending.ApplyTx(NewSignedTx(NewTx(from, "topic", 5), nil)))
+	assert.Nil(t, pending.ApplyTx(NewSignedTx(NewTransferTx(from, to, CoinUnit, 6), nil)))
+	assert.Equal(t, CoinUnit, pending.Balance(from))
+	assert.Equal(t, CoinUnit, pending.Balance(to))
+	assert.Equal(t, uint(6), pending.Nonce(from))
+
+	// the author can't spend more than is left after its pending txs
+	assert.NotNil(t, pending.ApplyTx(NewSignedTx(NewTransferTx(from, to, CoinUnit+1, 7), nil)))
+	assert.Equal(t, CoinUnit, pending.Balance(from))
+	// the recipient can spend what it is sent by pending txs
+	assert.Nil(t, pending.ApplyTx(NewSignedTx(NewTx(to, "topic", 1), nil)))
+	assert.Equal(t, uint64(0), pending.Balance(to))
+
+	// the committed state is untouched
+	assert.Equal(t, 3*CoinUnit, s.Catalog[from].Balance)
+	assert.Equal(t, uint(4), s.Account2Nonce[from])
+	_, ok := s.Catalog[to]
+	assert.False(t, ok)
+}
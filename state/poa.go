@@ -0,0 +1,410 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// the difficulty of a block sealed by the signer whose turn it is
+	diffInTurn = uint64(2)
+	// the difficulty of a block sealed by any other signer
+	diffNoTurn = uint64(1)
+	// the most an out-of-turn signer waits, per signer, before sealing
+	wiggleTime = 500 * time.Millisecond
+	// the most signer snapshots kept in memory, older ones are rebuilt from the blocks when needed
+	maxSignerSnapshots = 1024
+)
+
+// SignerFn signs a message with the sealer's key
+type SignerFn func(msg []byte) ([]byte, error)
+
+// ProofOfAuthority seals blocks with the signature of one of a set of authorized signers.
+// The signers take turns and can be voted in or out by the current signers with vote txs.
+type ProofOfAuthority struct {
+	// the signers listed in the genesis
+	genesisSigners []common.Address
+	// minimum seconds between blocks
+	period uint64
+
+	mu sync.Mutex
+	// the signer set after the most recently seen blocks
+	snapshots map[Hash]*signerSnapshot
+	// the hashes of the cached snapshots, oldest first
+	snapshotOrder []Hash
+	// the address and signing function of this node's sealer
+	signer common.Address
+	signFn SignerFn
+}
+
+func NewProofOfAuthority(genesis Genesis) *ProofOfAuthority {
+	return &ProofOfAuthority{
+		genesisSigners: genesis.Signers,
+		period:         genesis.BlockTime,
+		snapshots:      make(map[Hash]*signerSnapshot),
+	}
+}
+
+/*
+ Authorize the engine to seal blocks as 'signer' using 'signFn'
+*/
+func (poa *ProofOfAuthority) Authorize(signer common.Address, signFn SignerFn) {
+	poa.mu.Lock()
+	defer poa.mu.Unlock()
+	poa.signer = signer
+	poa.signFn = signFn
+}
+
+/*
+ The signers authorized to seal the block after 'hash'
+*/
+func (poa *ProofOfAuthority) Signers(chain ChainReader, hash Hash) ([]common.Address, error) {
+	snap, err := poa.snapshot(chain, hash)
+	if err != nil {
+		return nil, err
+	}
+	return snap.signerList(), nil
+}
+
+func (poa *ProofOfAuthority) Prepare(chain ChainReader, header *BlockHeader) error {
+	snap, err := poa.snapshot(chain, header.Parent)
+	if err != nil {
+		return err
+	}
+	if _, ok := snap.Signers[header.Miner]; !ok {
+		return fmt.Errorf("'%s' is not an authorized signer", header.Miner.Hex())
+	}
+	header.Difficulty = snap.difficulty(header.Number, header.Miner)
+	if !header.Parent.IsEmpty() {
+		parent, ok := chain.GetHeader(header.Parent)
+		if !ok {
+			return fmt.Errorf("parent block '%x' is unknown", header.Parent)
+		}
+		if header.Time < parent.Time+poa.period {
+			header.Time = parent.Time + poa.period
+		}
+	}
+	return nil
+}
+
+/*
+ Sign the block once its time has come. A signer whose turn it isn't waits a little
+ longer so the in-turn signer's block usually wins.
+*/
+func (poa *ProofOfAuthority) Seal(ctx context.Context, block Block) (Block, error) {
+	poa.mu.Lock()
+	signer, signFn := poa.signer, poa.signFn
+	poa.mu.Unlock()
+	if signFn == nil {
+		return Block{}, fmt.Errorf("no signer is authorized to seal blocks")
+	}
+	if block.Header.Miner != signer {
+		return Block{}, fmt.Errorf("block miner '%s' is not the authorized signer '%s'",
+			block.Header.Miner.Hex(), signer.Hex())
+	}
+
+	delay := time.Until(time.Unix(int64(block.Header.Time), 0))
+	if block.Header.Difficulty == diffNoTurn {
+		delay += time.Duration(rand.Int63n(int64(wiggleTime)))
+	}
+	select {
+	case <-ctx.Done():
+		logrus.Infoln("Sealing cancelled!")
+		return Block{}, fmt.Errorf("sealing cancelled. %s", ctx.Err())
+	case <-time.After(delay):
+	}
+
+	msg, err := sealMessage(block.Header)
+	if err != nil {
+		return Block{}, err
+	}
+	seal, err := signFn(msg)
+	if err != nil {
+		return Block{}, fmt.Errorf("couldn't seal block. %s", err.Error())
+	}
+	block.Header.Seal = seal
+	return block, nil
+}
+
+func (poa *ProofOfAuthority) VerifyHeader(chain ChainReader, header BlockHeader, hash Hash) error {
+	signer, err := recoverSigner(header)
+	if err != nil {
+		return err
+	}
+	if signer != header.Miner {
+		return fmt.Errorf("block is sealed by '%s' not its miner '%s'", signer.Hex(), header.Miner.Hex())
+	}
+	snap, err := poa.snapshot(chain, header.Parent)
+	if err != nil {
+		return err
+	}
+	if _, ok := snap.Signers[signer]; !ok {
+		return fmt.Errorf("'%s' is not an authorized signer", signer.Hex())
+	}
+	if snap.signedRecently(header.Number, signer) {
+		return fmt.Errorf("signer '%s' has sealed one of the last %d blocks", signer.Hex(), snap.recentLimit())
+	}
+	expected := snap.difficulty(header.Number, signer)
+	if header.Difficulty != expected {
+		return fmt.Errorf("block difficulty must be '%d' not '%d'", expected, header.Difficulty)
+	}
+	if !header.Parent.IsEmpty() {
+		parent, ok := chain.GetHeader(header.Parent)
+		if !ok {
+			return fmt.Errorf("parent block '%x' is unknown", header.Parent)
+		}
+		if header.Time < parent.Time+poa.period {
+			return fmt.Errorf("block time %d is less than %d seconds after its parent", header.Time, poa.period)
+		}
+	}
+	return verifyNotInFuture(header)
+}
+
+/*
+ The work of a block is its difficulty, so the chain sealed in turn is preferred
+*/
+func (poa *ProofOfAuthority) Work(header BlockHeader) *big.Int {
+	return new(big.Int).SetUint64(header.Difficulty)
+}
+
+/*
+ The signer snapshot as of the block 'hash', built from the closest known
+ snapshot by applying the vote txs of the blocks after it. The blocks are read
+ without holding the engine's lock, snapshots never change once built.
+*/
+func (poa *ProofOfAuthority) snapshot(chain ChainReader, hash Hash) (*signerSnapshot, error) {
+	blocks := make([]Block, 0)
+	var snap *signerSnapshot
+	for {
+		poa.mu.Lock()
+		cached, ok := poa.snapshots[hash]
+		poa.mu.Unlock()
+		if ok {
+			snap = cached
+			break
+		}
+		if hash.IsEmpty() {
+			snap = newSignerSnapshot(poa.genesisSigners)
+			break
+		}
+		block, ok, err := chain.GetBlock(hash)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("block '%x' is unknown", hash)
+		}
+		blocks = append(blocks, block)
+		hash = block.Header.Parent
+	}
+	poa.mu.Lock()
+	defer poa.mu.Unlock()
+	for i := len(blocks) - 1; i >= 0; i-- {
+		blockHash, err := blocks[i].Hash()
+		if err != nil {
+			return nil, err
+		}
+		snap = snap.apply(blocks[i])
+		poa.cacheSnapshot(blockHash, snap)
+	}
+	return snap, nil
+}
+
+/*
+ Cache the snapshot as of the block 'hash', forgetting the oldest snapshots beyond maxSignerSnapshots.
+ The caller holds the engine's lock.
+*/
+func (poa *ProofOfAuthority) cacheSnapshot(hash Hash, snap *signerSnapshot) {
+	if _, ok := poa.snapshots[hash]; ok {
+		return
+	}
+	poa.snapshots[hash] = snap
+	poa.snapshotOrder = append(poa.snapshotOrder, hash)
+	for len(poa.snapshotOrder) > maxSignerSnapshots {
+		delete(poa.snapshots, poa.snapshotOrder[0])
+		poa.snapshotOrder = poa.snapshotOrder[1:]
+	}
+}
+
+/*
+ The message a signer signs to seal a block: the header without its seal
+*/
+func sealMessage(header BlockHeader) ([]byte, error) {
+	header.Seal = nil
+	return json.Marshal(header)
+}
+
+/*
+ Recover the address that sealed the block
+*/
+func recoverSigner(header BlockHeader) (common.Address, error) {
+	if len(header.Seal) == 0 {
+		return common.Address{}, fmt.Errorf("block is not sealed")
+	}
+	msg, err := sealMessage(header)
+	if err != nil {
+		return common.Address{}, err
+	}
+	msgHash := sha256.Sum256(msg)
+	pubKey, err := crypto.SigToPub(msgHash[:], header.Seal)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid block seal. %s", err.Error())
+	}
+	pubKeyBytes := elliptic.Marshal(crypto.S256(), pubKey.X, pubKey.Y)
+	pubKeyHash := crypto.Keccak256(pubKeyBytes[1:])
+	return common.BytesToAddress(pubKeyHash[12:]), nil
+}
+
+// signerSnapshot is the signer set and the open votes as of a block
+type signerSnapshot struct {
+	Signers map[common.Address]struct{}
+	// votes on each proposed signer, by voter, true to authorize and false to remove
+	Votes map[common.Address]map[common.Address]bool
+	// the signer of each recent block, by block number
+	Recents map[uint64]common.Address
+}
+
+func newSignerSnapshot(signers []common.Address) *signerSnapshot {
+	snap := &signerSnapshot{
+		Signers: make(map[common.Address]struct{}),
+		Votes:   make(map[common.Address]map[common.Address]bool),
+		Recents: make(map[uint64]common.Address),
+	}
+	for _, signer := range signers {
+		snap.Signers[signer] = struct{}{}
+	}
+	return snap
+}
+
+func (snap *signerSnapshot) copy() *signerSnapshot {
+	c := newSignerSnapshot(snap.signerList())
+	for proposed, votes := range snap.Votes {
+		c.Votes[proposed] = make(map[common.Address]bool)
+		for voter, authorize := range votes {
+			c.Votes[proposed][voter] = authorize
+		}
+	}
+	for number, signer := range snap.Recents {
+		c.Recents[number] = signer
+	}
+	return c
+}
+
+/*
+ The signers sorted by address, which decides the order they take turns in
+*/
+func (snap *signerSnapshot) signerList() []common.Address {
+	signers := make([]common.Address, 0, len(snap.Signers))
+	for signer := range snap.Signers {
+		signers = append(signers, signer)
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(signers[i][:], signers[j][:]) < 0
+	})
+	return signers
+}
+
+func (snap *signerSnapshot) inTurn(number uint64, signer common.Address) bool {
+	signers := snap.signerList()
+	if len(signers) == 0 {
+		return false
+	}
+	return signers[number%uint64(len(signers))] == signer
+}
+
+func (snap *signerSnapshot) difficulty(number uint64, signer common.Address) uint64 {
+	if snap.inTurn(number, signer) {
+		return diffInTurn
+	}
+	return diffNoTurn
+}
+
+/*
+ A signer may seal only one of any recentLimit consecutive blocks
+*/
+func (snap *signerSnapshot) recentLimit() uint64 {
+	return uint64(len(snap.Signers)/2 + 1)
+}
+
+func (snap *signerSnapshot) signedRecently(number uint64, signer common.Address) bool {
+	for seen, recent := range snap.Recents {
+		if recent == signer && number < seen+snap.recentLimit() {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ The snapshot after the block: its signer is recorded as recent and the votes of its
+ txs are tallied. A proposal passes once more than half of the signers voted for it.
+*/
+func (snap *signerSnapshot) apply(block Block) *signerSnapshot {
+	next := snap.copy()
+	for _, tx := range block.TXs {
+		if tx.TxType() != TxTypeVote {
+			continue
+		}
+		next.vote(tx.Author, *tx.Vote)
+	}
+	next.Recents[block.Header.Number] = block.Header.Miner
+	for number := range next.Recents {
+		if number+next.recentLimit() <= block.Header.Number {
+			delete(next.Recents, number)
+		}
+	}
+	return next
+}
+
+func (snap *signerSnapshot) vote(voter common.Address, vote SignerVote) {
+	if _, ok := snap.Signers[voter]; !ok {
+		return
+	}
+	if _, isSigner := snap.Signers[vote.Signer]; isSigner == vote.Authorize {
+		// the vote wouldn't change anything
+		return
+	}
+	if snap.Votes[vote.Signer] == nil {
+		snap.Votes[vote.Signer] = make(map[common.Address]bool)
+	}
+	snap.Votes[vote.Signer][voter] = vote.Authorize
+
+	tally := 0
+	for _, authorize := range snap.Votes[vote.Signer] {
+		if authorize == vote.Authorize {
+			tally++
+		}
+	}
+	if tally <= len(snap.Signers)/2 {
+		return
+	}
+	delete(snap.Votes, vote.Signer)
+	if vote.Authorize {
+		logrus.Infof("Signer '%s' was voted in\n", vote.Signer.Hex())
+		snap.Signers[vote.Signer] = struct{}{}
+		return
+	}
+	logrus.Infof("Signer '%s' was voted out\n", vote.Signer.Hex())
+	delete(snap.Signers, vote.Signer)
+	for proposed := range snap.Votes {
+		delete(snap.Votes[proposed], vote.Signer)
+	}
+	for number, recent := range snap.Recents {
+		if recent == vote.Signer {
+			delete(snap.Recents, number)
+		}
+	}
+}
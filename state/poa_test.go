@@ -0,0 +1,163 @@
+package state
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSigner(t *testing.T) (common.Address, SignerFn) {
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+	return crypto.PubkeyToAddress(key.PublicKey), func(msg []byte) ([]byte, error) {
+		return testSign(msg, key)
+	}
+}
+
+func testSign(msg []byte, key *ecdsa.PrivateKey) ([]byte, error) {
+	msgHash := sha256.Sum256(msg)
+	return crypto.Sign(msgHash[:], key)
+}
+
+func sealTestBlock(t *testing.T, poa *ProofOfAuthority, s *State, signer common.Address, signFn SignerFn) (Block, error) {
+	block := NewBlock(s.tree.tip.hash, Hash{}, Hash{}, 0, s.tree.tip.header.Number+1, 0, nil, 0, signer, 0)
+	if err := poa.Prepare(s, &block.Header); err != nil {
+		return Block{}, err
+	}
+	poa.Authorize(signer, signFn)
+	return poa.Seal(context.Background(), block)
+}
+
+func Test_ProofOfAuthority_SealAndVerify(t *testing.T) {
+	first, signFirst := newTestSigner(t)
+	second, signSecond := newTestSigner(t)
+	outsider, signOutsider := newTestSigner(t)
+	poa := NewProofOfAuthority(Genesis{Signers: []common.Address{first, second}})
+	s := &State{tree: newBlockTree(), engine: poa}
+	// signers take turns in address order, 'a' seals block 1 in turn and 'b' block 2
+	a, signA, b, signB := first, signFirst, second, signSecond
+	if signers, _ := poa.Signers(s, Hash{}); signers[1] != a {
+		a, signA, b, signB = second, signSecond, first, signFirst
+	}
+
+	block, err := sealTestBlock(t, poa, s, a, signA)
+	assert.Nil(t, err)
+	assert.Equal(t, diffInTurn, block.Header.Difficulty)
+	hash, _ := block.Hash()
+	assert.Nil(t, poa.VerifyHeader(s, block.Header, hash))
+
+	// a seal by someone other than the miner
+	forged := block
+	forged.Header.Miner = b
+	assert.NotNil(t, poa.VerifyHeader(s, forged.Header, hash))
+
+	// a signer that isn't authorized can't prepare or seal a block
+	_, err = sealTestBlock(t, poa, s, outsider, signOutsider)
+	assert.NotNil(t, err)
+	outsiderBlock := NewBlock(Hash{}, Hash{}, Hash{}, 0, 1, diffNoTurn, nil, 0, outsider, 0)
+	poa.Authorize(outsider, signOutsider)
+	outsiderBlock, err = poa.Seal(context.Background(), outsiderBlock)
+	assert.Nil(t, err)
+	assert.NotNil(t, poa.VerifyHeader(s, outsiderBlock.Header, hash))
+
+	// the block after is b's turn, and a can't seal two blocks in a row
+	s.tree.tip = s.tree.add(hash, block.Header, poa.Work(block.Header), &block)
+	next, err := sealTestBlock(t, poa, s, b, signB)
+	assert.Nil(t, err)
+	assert.Equal(t, diffInTurn, next.Header.Difficulty)
+	nextHash, _ := next.Hash()
+	assert.Nil(t, poa.VerifyHeader(s, next.Header, nextHash))
+
+	again, err := sealTestBlock(t, poa, s, a, signA)
+	assert.Nil(t, err)
+	assert.Equal(t, diffNoTurn, again.Header.Difficulty)
+	againHash, _ := again.Hash()
+	assert.NotNil(t, poa.VerifyHeader(s, again.Header, againHash))
+}
+
+func Test_SignerSnapshot_Votes(t *testing.T) {
+	a := NewAddress("0x0000000000000000000000000000000000000001")
+	b := NewAddress("0x0000000000000000000000000000000000000002")
+	c := NewAddress("0x0000000000000000000000000000000000000003")
+	d := NewAddress("0x0000000000000000000000000000000000000004")
+	voteBlock := func(number uint64, voter common.Address, signer common.Address, authorize bool) Block {
+		tx := NewVoteTx(voter, signer, authorize, 1)
+		return Block{Header: BlockHeader{Number: number, Miner: voter}, TXs: []SignedTx{NewSignedTx(tx, nil)}}
+	}
+
+	snap := newSignerSnapshot([]common.Address{a, b, c})
+	// votes from non-signers don't count
+	snap = snap.apply(voteBlock(1, d, d, true))
+	assert.Empty(t, snap.Votes)
+	// one of three votes isn't a majority
+	snap = snap.apply(voteBlock(2, a, d, true))
+	assert.NotContains(t, snap.Signers, d)
+	snap = snap.apply(voteBlock(3, b, d, true))
+	assert.Contains(t, snap.Signers, d)
+	assert.Empty(t, snap.Votes)
+
+	// three of four votes are needed to remove a signer
+	snap = snap.apply(voteBlock(4, a, c, false))
+	snap = snap.apply(voteBlock(5, b, c, false))
+	assert.Contains(t, snap.Signers, c)
+	snap = snap.apply(voteBlock(6, d, c, false))
+	assert.NotContains(t, snap.Signers, c)
+	assert.Equal(t, []common.Address{a, b, d}, snap.signerList())
+}
+
+func Test_ProofOfAuthority_VerifyHeader_FutureTime(t *testing.T) {
+	signer, signFn := newTestSigner(t)
+	poa := NewProofOfAuthority(Genesis{Signers: []common.Address{signer}})
+	s := &State{tree: newBlockTree(), engine: poa}
+
+	// signed here rather than sealed, Seal waits until the block's time
+	for _, tc := range []struct {
+		time  time.Duration
+		valid bool
+	}{
+		{0, true},
+		{maxFutureBlockTime / 2, true},
+		{time.Hour, false},
+	} {
+		block := NewBlock(Hash{}, Hash{}, Hash{}, uint64(time.Now().Add(tc.time).Unix()), 1, 0, nil, 0, signer, 0)
+		assert.Nil(t, poa.Prepare(s, &block.Header))
+		msg, err := sealMessage(block.Header)
+		assert.Nil(t, err)
+		block.Header.Seal, err = signFn(msg)
+		assert.Nil(t, err)
+		hash, _ := block.Hash()
+		assert.Equal(t, tc.valid, poa.VerifyHeader(s, block.Header, hash) == nil, tc.time)
+	}
+}
+
+func Test_ProofOfAuthority_SnapshotCacheIsBounded(t *testing.T) {
+	signer, signFn := newTestSigner(t)
+	poa := NewProofOfAuthority(Genesis{Signers: []common.Address{signer}})
+	s := &State{tree: newBlockTree(), engine: poa}
+	block, err := sealTestBlock(t, poa, s, signer, signFn)
+	assert.Nil(t, err)
+	hash, _ := block.Hash()
+	s.tree.tip = s.tree.add(hash, block.Header, poa.Work(block.Header), &block)
+	_, err = poa.Signers(s, hash)
+	assert.Nil(t, err)
+
+	poa.mu.Lock()
+	for i := 0; i < maxSignerSnapshots; i++ {
+		poa.cacheSnapshot(Hash{byte(i), byte(i >> 8), 1}, newSignerSnapshot(nil))
+	}
+	poa.mu.Unlock()
+	assert.Len(t, poa.snapshots, maxSignerSnapshots)
+	assert.Len(t, poa.snapshotOrder, maxSignerSnapshots)
+	assert.NotContains(t, poa.snapshots, hash)
+
+	// a forgotten snapshot is rebuilt from the blocks
+	signers, err := poa.Signers(s, hash)
+	assert.Nil(t, err)
+	assert.Equal(t, []common.Address{signer}, signers)
+}
@@ -0,0 +1,333 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/raphamorim/go-rainbow"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// the difficulty of the hard-coded proof of work this chain started with (3 leading zero bytes)
+	DefaultDifficulty = uint64(1) << 24
+	// target seconds between blocks
+	DefaultBlockTime = uint64(15)
+	// the difficulty is adjusted every RetargetInterval blocks
+	DefaultRetargetInterval = uint64(10)
+	// the difficulty can change by at most this factor per retarget
+	maxRetargetFactor = 4
+	// how often the miner reports its hashrate
+	hashrateInterval = 5 * time.Second
+	// workers check for cancellation every hashrateBatch attempts
+	hashrateBatch = 1024
+	// a block's time must be after the median time of this many blocks before it
+	medianTimeBlocks = 11
+)
+
+var maxHash = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// ProofOfWork seals blocks by searching for a nonce that makes the block hash meet the difficulty target
+type ProofOfWork struct {
+	// the difficulty of the first block
+	difficulty uint64
+	// target seconds between blocks
+	blockTime uint64
+	// number of blocks between difficulty adjustments
+	retargetInterval uint64
+	// hashes per second of the current search
+	hashrate uint64
+	// number of nonce search workers, 0 for one per CPU
+	threads int32
+}
+
+func NewProofOfWork(genesis Genesis) *ProofOfWork {
+	return &ProofOfWork{
+		difficulty:       genesis.Difficulty,
+		blockTime:        genesis.BlockTime,
+		retargetInterval: genesis.RetargetInterval,
+	}
+}
+
+/*
+ The target a block hash must not exceed for the given difficulty
+*/
+func DifficultyTarget(difficulty uint64) *big.Int {
+	if difficulty == 0 {
+		difficulty = 1
+	}
+	return new(big.Int).Div(maxHash, new(big.Int).SetUint64(difficulty))
+}
+
+func (pow *ProofOfWork) Prepare(chain ChainReader, header *BlockHeader) error {
+	difficulty, err := pow.difficultyAfter(chain, header.Parent)
+	if err != nil {
+		return err
+	}
+	header.Difficulty = difficulty
+	if !header.Parent.IsEmpty() {
+		median, err := medianTimePast(chain, header.Parent)
+		if err != nil {
+			return err
+		}
+		// blocks mined within the same second still move the time forward
+		if header.Time <= median {
+			header.Time = median + 1
+		}
+	}
+	return nil
+}
+
+/*
+ The number of workers that search for a nonce, all CPUs by default
+*/
+func (pow *ProofOfWork) SetThreads(threads int) {
+	atomic.StoreInt32(&pow.threads, int32(threads))
+}
+
+func (pow *ProofOfWork) Threads() int {
+	threads := int(atomic.LoadInt32(&pow.threads))
+	if threads <= 0 {
+		return runtime.NumCPU()
+	}
+	return threads
+}
+
+/*
+ The hashes per second of the block being sealed, 0 when not sealing
+*/
+func (pow *ProofOfWork) Hashrate() uint64 {
+	return atomic.LoadUint64(&pow.hashrate)
+}
+
+/*
+ Search for a nonce that makes the block hash meet its difficulty target. The nonce
+ space is split into one range per worker and each worker walks its range in order,
+ rolling the extra-nonce when it runs out. All workers stop once a block is sealed
+ or the ctx is cancelled.
+*/
+func (pow *ProofOfWork) Seal(ctx context.Context, block Block) (Block, error) {
+	threads := pow.Threads()
+	searchCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	logrus.Infoln("Mining " + rainbow.Magenta(fmt.Sprintf("%d", len(block.TXs))) + " Pending TXs with " +
+		rainbow.Magenta(fmt.Sprintf("%d", threads)) + " workers")
+	var attempts uint64
+	found := make(chan Block, threads)
+	errs := make(chan error, threads)
+	var wg sync.WaitGroup
+	span := (uint64(math.MaxUint32) + 1) / uint64(threads)
+	for i := 0; i < threads; i++ {
+		first := uint64(i) * span
+		last := first + span - 1
+		if i == threads-1 {
+			last = math.MaxUint32
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pow.search(searchCtx, block, uint32(first), uint32(last), &attempts, found, errs)
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	go pow.reportHashrate(searchCtx, len(block.TXs), &attempts)
+	defer atomic.StoreUint64(&pow.hashrate, 0)
+
+	select {
+	case sealed := <-found:
+		stop()
+		<-done
+		return sealed, nil
+	case err := <-errs:
+		stop()
+		<-done
+		return Block{}, err
+	case <-done:
+		// the last worker may have sealed the block, or failed, just before the workers were all done
+		select {
+		case sealed := <-found:
+			return sealed, nil
+		case err := <-errs:
+			return Block{}, err
+		default:
+		}
+		if ctx.Err() == nil {
+			return Block{}, fmt.Errorf("couldn't mine block. the workers stopped without sealing it")
+		}
+		logrus.Infoln("Mining cancelled!")
+		return Block{}, fmt.Errorf(rainbow.Red("mining cancelled. %s"), ctx.Err())
+	}
+}
+
+/*
+ Walk the nonces from 'first' to 'last' in order, then roll the extra-nonce and start over
+*/
+func (pow *ProofOfWork) search(ctx context.Context, block Block, first uint32, last uint32,
+	attempts *uint64, found chan<- Block, errs chan<- error) {
+	attempt := 0
+	for extraNonce := uint32(0); ; extraNonce++ {
+		block.Header.ExtraNonce = extraNonce
+		for nonce := first; ; nonce++ {
+			if attempt%hashrateBatch == 0 && ctx.Err() != nil {
+				return
+			}
+			attempt++
+			block.Header.Nonce = nonce
+			block.Header.PoW = attempt
+			hash, err := block.Hash()
+			if err != nil {
+				errs <- fmt.Errorf("couldn't mine block. %s", err.Error())
+				return
+			}
+			atomic.AddUint64(attempts, 1)
+			if IsBlockHashValid(hash, block.Header.Difficulty) {
+				found <- block
+				return
+			}
+			if nonce == last {
+				break
+			}
+		}
+	}
+}
+
+/*
+ Log the hashrate every hashrateInterval until the ctx is done
+*/
+func (pow *ProofOfWork) reportHashrate(ctx context.Context, txs int, attempts *uint64) {
+	ticker := time.NewTicker(hashrateInterval)
+	defer ticker.Stop()
+	last := uint64(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			total := atomic.LoadUint64(attempts)
+			hashrate := uint64(float64(total-last) / hashrateInterval.Seconds())
+			last = total
+			atomic.StoreUint64(&pow.hashrate, hashrate)
+			logrus.Infoln("Mining " + rainbow.Magenta(fmt.Sprintf("%d", txs)) + " Pending TXs. Attempts: " +
+				rainbow.Magenta(fmt.Sprintf("%d", total)) + " Hashrate: " + rainbow.Magenta(fmt.Sprintf("%d H/s", hashrate)))
+		}
+	}
+}
+
+func (pow *ProofOfWork) VerifyHeader(chain ChainReader, header BlockHeader, hash Hash) error {
+	expected, err := pow.difficultyAfter(chain, header.Parent)
+	if err != nil {
+		return err
+	}
+	if header.Difficulty != expected {
+		return fmt.Errorf("block difficulty must be '%d' not '%d'", expected, header.Difficulty)
+	}
+	// the difficulty is retargeted from the block times, so they can't be made up
+	if !header.Parent.IsEmpty() {
+		median, err := medianTimePast(chain, header.Parent)
+		if err != nil {
+			return err
+		}
+		if header.Time <= median {
+			return fmt.Errorf("block time %d must be after %d, the median time of the blocks before it", header.Time, median)
+		}
+	}
+	if err := verifyNotInFuture(header); err != nil {
+		return err
+	}
+	if !IsBlockHashValid(hash, header.Difficulty) {
+		return fmt.Errorf(rainbow.Red("Invalid block hash %x"), hash)
+	}
+	return nil
+}
+
+/*
+ The median time of the medianTimeBlocks blocks up to and including 'parentHash'
+*/
+func medianTimePast(chain ChainReader, parentHash Hash) (uint64, error) {
+	times := make([]uint64, 0, medianTimeBlocks)
+	for hash := parentHash; !hash.IsEmpty() && len(times) < medianTimeBlocks; {
+		header, ok := chain.GetHeader(hash)
+		if !ok {
+			return 0, fmt.Errorf("ancestor block '%x' is unknown", hash)
+		}
+		times = append(times, header.Time)
+		hash = header.Parent
+	}
+	if len(times) == 0 {
+		return 0, nil
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	return times[len(times)/2], nil
+}
+
+/*
+ The work of a block is its difficulty, the expected number of hashes needed to find it
+*/
+func (pow *ProofOfWork) Work(header BlockHeader) *big.Int {
+	return new(big.Int).SetUint64(header.Difficulty)
+}
+
+/*
+ Calculate the difficulty of the block after 'parentHash', retargeting every retargetInterval
+ blocks so that blocks are found every blockTime seconds on average
+*/
+func (pow *ProofOfWork) difficultyAfter(chain ChainReader, parentHash Hash) (uint64, error) {
+	if parentHash.IsEmpty() {
+		return pow.difficulty, nil
+	}
+	parent, ok := chain.GetHeader(parentHash)
+	if !ok {
+		return 0, fmt.Errorf("parent block '%x' is unknown", parentHash)
+	}
+	if parent.Number%pow.retargetInterval != 0 {
+		return parent.Difficulty, nil
+	}
+	// find the first block of the interval that just ended
+	first := parent
+	for i := uint64(1); i < pow.retargetInterval && !first.Parent.IsEmpty(); i++ {
+		ancestorHash := first.Parent
+		first, ok = chain.GetHeader(ancestorHash)
+		if !ok {
+			return 0, fmt.Errorf("ancestor block '%x' is unknown", ancestorHash)
+		}
+	}
+	expected := int64(pow.blockTime * (parent.Number - first.Number))
+	actual := int64(parent.Time) - int64(first.Time)
+	if expected <= 0 {
+		return parent.Difficulty, nil
+	}
+	if actual <= 0 {
+		actual = 1
+	}
+	parentDifficulty := new(big.Int).SetUint64(parent.Difficulty)
+	next := new(big.Int).Mul(parentDifficulty, big.NewInt(expected))
+	next.Div(next, big.NewInt(actual))
+	// clamp the adjustment so a handful of bad timestamps can't swing the difficulty
+	maxNext := new(big.Int).Mul(parentDifficulty, big.NewInt(maxRetargetFactor))
+	minNext := new(big.Int).Div(parentDifficulty, big.NewInt(maxRetargetFactor))
+	if next.Cmp(maxNext) > 0 {
+		next = maxNext
+	}
+	if next.Cmp(minNext) < 0 {
+		next = minNext
+	}
+	if next.Sign() <= 0 {
+		return 1, nil
+	}
+	if !next.IsUint64() {
+		return ^uint64(0), nil
+	}
+	return next.Uint64(), nil
+}
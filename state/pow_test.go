@@ -0,0 +1,149 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testChain struct {
+	*State
+	pow *ProofOfWork
+}
+
+func buildTestChain(genesis Genesis, blocks uint64, secondsPerBlock uint64) testChain {
+	pow := NewProofOfWork(genesis)
+	s := &State{tree: newBlockTree(), engine: pow}
+	for number := uint64(1); number <= blocks; number++ {
+		header := BlockHeader{Parent: s.tree.tip.hash, Number: number, Time: number * secondsPerBlock}
+		if err := pow.Prepare(s, &header); err != nil {
+			panic(err)
+		}
+		s.tree.tip = s.tree.add(Hash{byte(number)}, header, pow.Work(header), nil)
+	}
+	return testChain{s, pow}
+}
+
+func (c testChain) NextDifficulty() uint64 {
+	header := BlockHeader{Parent: c.tree.tip.hash}
+	if err := c.pow.Prepare(c.State, &header); err != nil {
+		panic(err)
+	}
+	return header.Difficulty
+}
+
+func Test_NextDifficulty_FromGenesis(t *testing.T) {
+	genesis := Genesis{Difficulty: 1000, BlockTime: 15, RetargetInterval: 10}
+	s := buildTestChain(genesis, 0, 15)
+	assert.Equal(t, uint64(1000), s.NextDifficulty())
+}
+
+func Test_NextDifficulty_Retargets(t *testing.T) {
+	genesis := Genesis{Difficulty: 1000, BlockTime: 15, RetargetInterval: 10}
+
+	// on target: unchanged
+	assert.Equal(t, uint64(1000), buildTestChain(genesis, 10, 15).NextDifficulty())
+	// twice as slow: halved
+	assert.Equal(t, uint64(500), buildTestChain(genesis, 10, 30).NextDifficulty())
+	// three times as fast: tripled
+	assert.Equal(t, uint64(3000), buildTestChain(genesis, 10, 5).NextDifficulty())
+	// only changes at the retarget interval
+	assert.Equal(t, uint64(500), buildTestChain(genesis, 15, 30).NextDifficulty())
+}
+
+func Test_ProofOfWork_VerifyHeader(t *testing.T) {
+	genesis := Genesis{Difficulty: 1, BlockTime: 15, RetargetInterval: 10}
+	pow := NewProofOfWork(genesis)
+	s := &State{tree: newBlockTree(), engine: pow}
+	block := NewBlock(Hash{}, Hash{}, Hash{}, 1, 1, 0, nil, 0, NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD"), 0)
+	assert.Nil(t, pow.Prepare(s, &block.Header))
+	sealed, err := pow.Seal(context.Background(), block)
+	assert.Nil(t, err)
+	hash, err := sealed.Hash()
+	assert.Nil(t, err)
+	assert.Nil(t, pow.VerifyHeader(s, sealed.Header, hash))
+
+	sealed.Header.Difficulty = 2
+	assert.NotNil(t, pow.VerifyHeader(s, sealed.Header, hash))
+}
+
+func Test_ProofOfWork_VerifyHeader_Time(t *testing.T) {
+	genesis := Genesis{Difficulty: 1, BlockTime: 15, RetargetInterval: 10}
+	chain := buildTestChain(genesis, 11, 15)
+	// difficulty 1 accepts any non-empty hash
+	hash := Hash{1}
+	header := BlockHeader{Parent: chain.tree.tip.hash, Number: 12, Time: 15 * 12}
+	assert.Nil(t, chain.pow.Prepare(chain.State, &header))
+	assert.Nil(t, chain.pow.VerifyHeader(chain.State, header, hash))
+
+	// a block can't go back to before the median time of the blocks before it
+	header.Time = 15 * 6
+	assert.NotNil(t, chain.pow.VerifyHeader(chain.State, header, hash))
+	header.Time = 15*6 + 1
+	assert.Nil(t, chain.pow.VerifyHeader(chain.State, header, hash))
+
+	// or run ahead of the clock
+	header.Time = uint64(time.Now().Add(time.Hour).Unix())
+	assert.NotNil(t, chain.pow.VerifyHeader(chain.State, header, hash))
+
+	// a block mined in the same second as its parent is moved forward
+	header.Time = 15 * 6
+	assert.Nil(t, chain.pow.Prepare(chain.State, &header))
+	assert.Equal(t, uint64(15*6+1), header.Time)
+}
+
+func Test_NextDifficulty_IsClamped(t *testing.T) {
+	genesis := Genesis{Difficulty: 1000, BlockTime: 15, RetargetInterval: 10}
+	assert.Equal(t, uint64(4000), buildTestChain(genesis, 10, 0).NextDifficulty())
+	assert.Equal(t, uint64(250), buildTestChain(genesis, 10, 600).NextDifficulty())
+}
+
+func Test_ProofOfWork_SealWithWorkers(t *testing.T) {
+	genesis := Genesis{Difficulty: 1000, BlockTime: 15, RetargetInterval: 10}
+	pow := NewProofOfWork(genesis)
+	pow.SetThreads(4)
+	block := NewBlock(Hash{}, Hash{}, Hash{}, 1, 1, 1000, nil, 0, NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD"), 0)
+	sealed, err := pow.Seal(context.Background(), block)
+	assert.Nil(t, err)
+	hash, err := sealed.Hash()
+	assert.Nil(t, err)
+	assert.True(t, IsBlockHashValid(hash, 1000))
+	assert.Equal(t, uint64(0), pow.Hashrate())
+}
+
+func Test_ProofOfWork_SealWithOneWorker(t *testing.T) {
+	pow := NewProofOfWork(Genesis{})
+	pow.SetThreads(1)
+	block := NewBlock(Hash{}, Hash{}, Hash{}, 1, 1, 1, nil, 0, NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD"), 0)
+	// the only worker seals the block and is done at once, which must never read as a cancellation
+	for i := 0; i < 20000; i++ {
+		_, err := pow.Seal(context.Background(), block)
+		assert.Nil(t, err)
+	}
+}
+
+func Test_ProofOfWork_SearchRollsExtraNonce(t *testing.T) {
+	pow := NewProofOfWork(Genesis{})
+	block := NewBlock(Hash{}, Hash{}, Hash{}, 1, 1, 1000, nil, 0, NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD"), 0)
+	found := make(chan Block, 1)
+	errs := make(chan error, 1)
+	var attempts uint64
+	// a range of a single nonce can only be searched by rolling the extra-nonce
+	pow.search(context.Background(), block, 7, 7, &attempts, found, errs)
+	sealed := <-found
+	assert.Equal(t, uint32(7), sealed.Header.Nonce)
+	assert.True(t, sealed.Header.ExtraNonce > 0)
+	assert.Equal(t, uint64(sealed.Header.PoW), attempts)
+}
+
+func Test_ProofOfWork_SealCancelled(t *testing.T) {
+	pow := NewProofOfWork(Genesis{})
+	pow.SetThreads(2)
+	block := NewBlock(Hash{}, Hash{}, Hash{}, 1, 1, ^uint64(0), nil, 0, NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD"), 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := pow.Seal(ctx, block)
+	assert.NotNil(t, err)
+}
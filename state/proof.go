@@ -0,0 +1,38 @@
+package state
+
+// TxProof proves a tx is included in the block with hash 'BlockHash'
+type TxProof struct {
+	BlockHash Hash        `json:"block_hash"`
+	Header    BlockHeader `json:"header"`
+	TxHash    Hash        `json:"tx_hash"`
+	Index     int         `json:"index"`
+	TxCount   int         `json:"tx_count"`
+	Siblings  []Hash      `json:"siblings"`
+}
+
+/*
+ Build the Merkle inclusion proof for the tx with the given hash
+*/
+func (s *State) GetTxProof(txHash Hash) (TxProof, bool, error) {
+	blockHash, index, ok := s.store.GetTxLocation(txHash)
+	if !ok {
+		return TxProof{}, false, nil
+	}
+	block, ok, err := s.store.Get(blockHash)
+	if err != nil || !ok {
+		return TxProof{}, false, err
+	}
+	leaves, err := txHashes(block.TXs)
+	if err != nil {
+		return TxProof{}, false, err
+	}
+	return TxProof{blockHash, block.Header, txHash, index, len(leaves), MerkleProof(leaves, index)}, true, nil
+}
+
+/*
+ Verify the tx with the given hash is included in the block with the given header.
+ Only the header is needed, not the block's txs.
+*/
+func VerifyTxProof(header BlockHeader, txHash Hash, index int, txCount int, siblings []Hash) bool {
+	return VerifyMerkleProof(header.TxRoot, txHash, index, txCount, siblings)
+}
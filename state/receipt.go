@@ -0,0 +1,229 @@
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// receipts.db keeps the receipts of this many failed txs, the oldest are forgotten first
+const MaxFailedReceipts = 10000
+
+// TxStatus is how far a tx got on its way into the chain
+type TxStatus string
+
+const (
+	// the node has never seen the tx
+	TxStatusUnknown TxStatus = "unknown"
+	// the tx is in the mempool, waiting to be mined
+	TxStatusPending TxStatus = "pending"
+	// the tx is included in a block of the canonical chain
+	TxStatusMined TxStatus = "mined"
+	// the tx was dropped before it was mined, its receipt says why
+	TxStatusFailed TxStatus = "failed"
+)
+
+// Receipt is the outcome of a tx: the block it was mined in, or why it was dropped
+type Receipt struct {
+	TxHash Hash     `json:"tx_hash"`
+	Status TxStatus `json:"status"`
+	// the block the tx was mined in and its position in it, only for mined txs
+	BlockHash Hash   `json:"block_hash"`
+	Height    uint64 `json:"height"`
+	Index     int    `json:"index"`
+	// why the tx was dropped, only for failed txs
+	Reason string `json:"reason,omitempty"`
+}
+
+/*
+ receiptStore keeps the receipts of the latest 'limit' failed txs in the JSON-lines
+ receipts.db. The receipts of mined txs aren't written to it, they are built from the
+ block store's tx index so they follow the canonical chain through reorgs.
+*/
+type receiptStore struct {
+	mu       sync.RWMutex
+	path     string
+	file     *os.File
+	limit    int
+	receipts map[Hash]Receipt
+	// the hashes of the receipts, oldest first
+	order []Hash
+	// the number of records in the file, including replaced and forgotten receipts
+	records int
+}
+
+/*
+ Open the receipt store at 'path', loading its receipts. A torn record at the tail of
+ the file (e.g. from a crash mid-write) is truncated. The file is compacted if it holds
+ records of receipts that were replaced or forgotten.
+*/
+func newReceiptStore(path string, limit int) (*receiptStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	store := &receiptStore{
+		path:     path,
+		file:     f,
+		limit:    limit,
+		receipts: make(map[Hash]Receipt),
+	}
+	reader := bufio.NewReader(f)
+	offset := int64(0)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			f.Close()
+			return nil, err
+		}
+		if len(line) == 0 {
+			break
+		}
+		var receipt Receipt
+		if line[len(line)-1] != '\n' || json.Unmarshal(line, &receipt) != nil {
+			if _, peekErr := reader.Peek(1); peekErr != io.EOF {
+				f.Close()
+				return nil, fmt.Errorf("corrupt receipt record in %s", path)
+			}
+			logrus.Warnf("Discarding corrupt receipt record at the tail of %s (offset %d)\n", path, offset)
+			// cut the torn record so the next receipt starts on its own line
+			if err := f.Truncate(offset); err != nil {
+				f.Close()
+				return nil, err
+			}
+			break
+		}
+		offset += int64(len(line))
+		store.records++
+		store.add(receipt)
+	}
+	if store.records > len(store.order) {
+		if err := store.compact(); err != nil {
+			store.file.Close()
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+/*
+ Add the receipt to the receipts in memory. A later receipt of the same tx replaces
+ the earlier one, and the oldest receipts are forgotten beyond the limit.
+*/
+func (s *receiptStore) add(receipt Receipt) {
+	if _, ok := s.receipts[receipt.TxHash]; !ok {
+		s.order = append(s.order, receipt.TxHash)
+	}
+	s.receipts[receipt.TxHash] = receipt
+	for len(s.order) > s.limit {
+		delete(s.receipts, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+/*
+ Rewrite the file with only the receipts in memory. The file is written to a temp
+ file and renamed, so a crash leaves either the old or the compacted file.
+*/
+func (s *receiptStore) compact() error {
+	var content []byte
+	for _, txHash := range s.order {
+		receiptJSON, err := json.Marshal(s.receipts[txHash])
+		if err != nil {
+			return err
+		}
+		content = append(append(content, receiptJSON...), '\n')
+	}
+	tmpPath := s.path + ".tmp"
+	if err := writeFileSync(tmpPath, content); err != nil {
+		return err
+	}
+	if err := Rename(tmpPath, s.path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	s.file.Close()
+	s.file = f
+	s.records = len(s.order)
+	return nil
+}
+
+func (s *receiptStore) get(txHash Hash) (Receipt, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	receipt, ok := s.receipts[txHash]
+	return receipt, ok
+}
+
+func (s *receiptStore) put(receipt Receipt) error {
+	receiptJSON, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(receiptJSON, '\n')); err != nil {
+		return err
+	}
+	s.records++
+	s.add(receipt)
+	// a long running node compacts the file once it is twice the size of its receipts
+	if s.records >= 2*s.limit {
+		return s.compact()
+	}
+	return nil
+}
+
+func (s *receiptStore) close() error {
+	return s.file.Close()
+}
+
+/*
+ Record that the tx was dropped before it was mined, and why
+*/
+func (s *State) AddFailedReceipt(txHash Hash, reason error) error {
+	if s.receipts == nil {
+		return nil
+	}
+	return s.receipts.put(Receipt{TxHash: txHash, Status: TxStatusFailed, Reason: reason.Error()})
+}
+
+/*
+ The receipt of a tx that was mined in the canonical chain or was dropped. False if the chain
+ doesn't know the tx, it may still be pending.
+*/
+func (s *State) GetReceipt(txHash Hash) (Receipt, bool) {
+	if blockHash, index, ok := s.store.GetTxLocation(txHash); ok {
+		if header, ok := s.GetHeader(blockHash); ok {
+			return Receipt{TxHash: txHash, Status: TxStatusMined, BlockHash: blockHash,
+				Height: header.Number, Index: index}, true
+		}
+	}
+	if s.receipts == nil {
+		return Receipt{}, false
+	}
+	return s.receipts.get(txHash)
+}
+
+/*
+ The tx with the given hash from the canonical chain, and its receipt
+*/
+func (s *State) GetMinedTx(txHash Hash) (SignedTx, Receipt, bool, error) {
+	receipt, ok := s.GetReceipt(txHash)
+	if !ok || receipt.Status != TxStatusMined {
+		return SignedTx{}, Receipt{}, false, nil
+	}
+	block, ok, err := s.store.Get(receipt.BlockHash)
+	if err != nil || !ok || receipt.Index >= len(block.TXs) {
+		return SignedTx{}, Receipt{}, false, err
+	}
+	return block.TXs[receipt.Index], receipt, true, nil
+}
@@ -0,0 +1,95 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_receiptStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "receipt_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "receipts.db")
+
+	store, err := newReceiptStore(path, MaxFailedReceipts)
+	assert.Nil(t, err)
+	s := &State{receipts: store}
+	first, second := Hash{1}, Hash{2}
+	assert.Nil(t, s.AddFailedReceipt(first, fmt.Errorf("expired")))
+	assert.Nil(t, s.AddFailedReceipt(second, fmt.Errorf("evicted")))
+	assert.Nil(t, s.AddFailedReceipt(first, fmt.Errorf("replaced")))
+	assert.Nil(t, store.close())
+
+	// the receipts are read back after a restart, a torn record at the tail is cut off
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	assert.Nil(t, err)
+	_, err = f.Write([]byte(`{"tx_hash":`))
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+	store, err = newReceiptStore(path, MaxFailedReceipts)
+	assert.Nil(t, err)
+	defer store.close()
+	receipt, ok := store.get(first)
+	assert.True(t, ok)
+	assert.Equal(t, Receipt{TxHash: first, Status: TxStatusFailed, Reason: "replaced"}, receipt)
+	_, ok = store.get(Hash{3})
+	assert.False(t, ok)
+
+	// receipts written after the torn record are read back too
+	s = &State{receipts: store}
+	assert.Nil(t, s.AddFailedReceipt(Hash{3}, fmt.Errorf("dropped")))
+	assert.Nil(t, store.close())
+	store, err = newReceiptStore(path, MaxFailedReceipts)
+	assert.Nil(t, err)
+	_, ok = store.get(Hash{3})
+	assert.True(t, ok)
+}
+
+func Test_receiptStore_Limit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "receipt_test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "receipts.db")
+
+	store, err := newReceiptStore(path, 3)
+	assert.Nil(t, err)
+	s := &State{receipts: store}
+	for i := byte(1); i <= 5; i++ {
+		assert.Nil(t, s.AddFailedReceipt(Hash{i}, fmt.Errorf("dropped")))
+	}
+	assert.Nil(t, s.AddFailedReceipt(Hash{5}, fmt.Errorf("replaced")))
+
+	// only the latest receipts are kept
+	_, ok := store.get(Hash{2})
+	assert.False(t, ok)
+	for i := byte(3); i <= 5; i++ {
+		_, ok = store.get(Hash{i})
+		assert.True(t, ok)
+	}
+	// and the file is compacted once it holds twice as many records
+	assert.Equal(t, 3, countLines(t, path))
+	assert.Nil(t, store.close())
+
+	// on open too
+	store, err = newReceiptStore(path, 2)
+	assert.Nil(t, err)
+	defer store.close()
+	assert.Equal(t, 2, countLines(t, path))
+	receipt, ok := store.get(Hash{5})
+	assert.True(t, ok)
+	assert.Equal(t, "replaced", receipt.Reason)
+	_, ok = store.get(Hash{3})
+	assert.False(t, ok)
+}
+
+func countLines(t *testing.T, path string) int {
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	return bytes.Count(content, []byte("\n"))
+}
@@ -0,0 +1,233 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RewardSchedule decides how many coins the coinbase tx of each block may mint
+type RewardSchedule struct {
+	// the reward of the first block, in base units
+	Initial uint64 `json:"initial"`
+	// the reward halves every HalvingInterval blocks, 0 to never halve
+	HalvingInterval uint64 `json:"halving_interval"`
+	// the most base units there can be, including the genesis balances, 0 for no cap
+	MaxSupply uint64 `json:"max_supply"`
+}
+
+// Coinbase mints the block reward, it is the first tx of every block
+type Coinbase struct {
+	// the number of the block, so the coinbase txs of different blocks never share a hash
+	Height  uint64   `json:"height"`
+	Payouts []Payout `json:"payouts"`
+}
+
+// Payout is the part of a block reward paid to an address
+type Payout struct {
+	To     common.Address `json:"to"`
+	Amount uint64         `json:"amount"`
+}
+
+// RewardShare is an address's weight when splitting a block reward
+type RewardShare struct {
+	To     common.Address
+	Weight uint64
+}
+
+/*
+ The coinbase tx of the block at 'height', paying out the block reward.
+ Coinbase txs have no author and are not signed.
+*/
+func NewCoinbaseTx(height uint64, payouts []Payout) SignedTx {
+	return NewSignedTx(Tx{Coinbase: &Coinbase{height, payouts}, Type: TxTypeCoinbase}, nil)
+}
+
+/*
+ The scheduled reward of the block at 'height', before the supply cap. Blocks start at
+ height 1, so every era of HalvingInterval blocks starts one block after a multiple of it.
+*/
+func (r RewardSchedule) scheduled(height uint64) uint64 {
+	if r.HalvingInterval == 0 || height == 0 {
+		return r.Initial
+	}
+	halvings := (height - 1) / r.HalvingInterval
+	if halvings >= 64 {
+		return 0
+	}
+	return r.Initial >> halvings
+}
+
+/*
+ The coins minted by the scheduled rewards of the blocks from height 1 to before 'height',
+ before the supply cap
+*/
+func (r RewardSchedule) mintedBefore(height uint64) uint64 {
+	if height <= 1 {
+		return 0
+	}
+	mined := height - 1
+	if r.HalvingInterval == 0 {
+		return saturatingMul(r.Initial, mined)
+	}
+	minted := uint64(0)
+	// 'start' counts the blocks mined before the era
+	for start := uint64(0); start < mined; start += r.HalvingInterval {
+		reward := r.scheduled(start + 1)
+		if reward == 0 {
+			break
+		}
+		blocks := r.HalvingInterval
+		if mined-start < blocks {
+			blocks = mined - start
+		}
+		minted = saturatingAdd(minted, saturatingMul(reward, blocks))
+		if start+r.HalvingInterval < start {
+			break
+		}
+	}
+	return minted
+}
+
+/*
+ The reward of the block at 'height', given the coins allocated in the genesis
+*/
+func (r RewardSchedule) BlockReward(height uint64, genesisSupply uint64) uint64 {
+	reward := r.scheduled(height)
+	if r.MaxSupply == 0 {
+		return reward
+	}
+	supply := saturatingAdd(genesisSupply, r.mintedBefore(height))
+	if supply >= r.MaxSupply {
+		return 0
+	}
+	if remaining := r.MaxSupply - supply; reward > remaining {
+		return remaining
+	}
+	return reward
+}
+
+/*
+ Split the reward across the shares by weight. The remainder of the division goes to the first share.
+ Shares whose part rounds down to nothing are left out, coinbase payouts must be positive.
+*/
+func SplitReward(reward uint64, shares []RewardShare) []Payout {
+	totalWeight := new(big.Int)
+	for _, share := range shares {
+		totalWeight.Add(totalWeight, new(big.Int).SetUint64(share.Weight))
+	}
+	payouts := make([]Payout, 0, len(shares))
+	if totalWeight.Sign() == 0 || reward == 0 {
+		return payouts
+	}
+	amounts := make([]uint64, len(shares))
+	paid := uint64(0)
+	for i, share := range shares {
+		// reward * weight / totalWeight is at most the reward, so it fits in a uint64
+		amount := new(big.Int).Mul(new(big.Int).SetUint64(reward), new(big.Int).SetUint64(share.Weight))
+		amounts[i] = amount.Div(amount, totalWeight).Uint64()
+		paid += amounts[i]
+	}
+	amounts[0] += reward - paid
+	for i, share := range shares {
+		if amounts[i] > 0 {
+			payouts = append(payouts, Payout{share.To, amounts[i]})
+		}
+	}
+	return payouts
+}
+
+/*
+ Check the shares can split block rewards: every share has a weight and the weights
+ add up without overflowing
+*/
+func ValidateRewardShares(shares []RewardShare) error {
+	totalWeight := uint64(0)
+	for _, share := range shares {
+		if share.Weight == 0 {
+			return fmt.Errorf("the reward share of '%s' must have a weight", share.To.Hex())
+		}
+		if saturatingAdd(totalWeight, share.Weight) == ^uint64(0) {
+			return fmt.Errorf("the reward share weights add up to more than %d", ^uint64(0)-1)
+		}
+		totalWeight += share.Weight
+	}
+	return nil
+}
+
+/*
+ The coins allocated to accounts in the genesis
+*/
+func (g Genesis) Supply() uint64 {
+	supply := uint64(0)
+	for _, account := range g.State {
+		supply = saturatingAdd(supply, account.Balance)
+	}
+	return supply
+}
+
+/*
+ The reward of the next block, false if the chain credits the miner implicitly
+ because its genesis has no reward schedule
+*/
+func (s *State) NextBlockReward() (uint64, bool) {
+	if s.genesis.Reward == nil {
+		return 0, false
+	}
+	return s.genesis.Reward.BlockReward(s.NextBlockNumber(), s.genesis.Supply()), true
+}
+
+/*
+ Check that the coinbase tx pays out exactly the reward of the block at 'height'
+*/
+func validateCoinbase(tx SignedTx, height uint64, reward uint64) error {
+	if tx.TxType() != TxTypeCoinbase {
+		return fmt.Errorf("the first tx of a block must be its coinbase")
+	}
+	if err := tx.ValidatePayload(); err != nil {
+		return err
+	}
+	if tx.Coinbase.Height != height {
+		return fmt.Errorf("coinbase height must be '%d' not '%d'", height, tx.Coinbase.Height)
+	}
+	if (tx.Author != common.Address{}) || len(tx.Sig) != 0 {
+		return fmt.Errorf("coinbase tx must not have an author or signature")
+	}
+	total := uint64(0)
+	for _, payout := range tx.Coinbase.Payouts {
+		if payout.Amount == 0 {
+			return fmt.Errorf("coinbase payout to '%s' must be positive", payout.To.Hex())
+		}
+		total = saturatingAdd(total, payout.Amount)
+	}
+	if total != reward {
+		return fmt.Errorf("coinbase pays out '%d' but the block reward is '%d'", total, reward)
+	}
+	return nil
+}
+
+/*
+ Credit the coinbase payouts
+*/
+func applyCoinbase(coinbase Coinbase, s *State) {
+	for _, payout := range coinbase.Payouts {
+		account := s.Catalog[payout.To]
+		account.Balance += payout.Amount
+		s.Catalog[payout.To] = account
+	}
+}
+
+func saturatingAdd(a uint64, b uint64) uint64 {
+	if a+b < a {
+		return ^uint64(0)
+	}
+	return a + b
+}
+
+func saturatingMul(a uint64, b uint64) uint64 {
+	if a != 0 && b > ^uint64(0)/a {
+		return ^uint64(0)
+	}
+	return a * b
+}
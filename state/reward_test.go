@@ -0,0 +1,106 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RewardSchedule_Halving(t *testing.T) {
+	schedule := RewardSchedule{Initial: 10, HalvingInterval: 100}
+	// blocks start at height 1, so the first era is blocks 1 to 100
+	assert.Equal(t, uint64(10), schedule.BlockReward(1, 0))
+	assert.Equal(t, uint64(10), schedule.BlockReward(100, 0))
+	assert.Equal(t, uint64(5), schedule.BlockReward(101, 0))
+	assert.Equal(t, uint64(2), schedule.BlockReward(250, 0))
+	assert.Equal(t, uint64(1), schedule.BlockReward(400, 0))
+	assert.Equal(t, uint64(0), schedule.BlockReward(401, 0))
+	assert.Equal(t, uint64(0), schedule.mintedBefore(1))
+	assert.Equal(t, uint64(1000+500+200), schedule.mintedBefore(301))
+}
+
+func Test_RewardSchedule_SupplyCap(t *testing.T) {
+	// 1000 coins in the genesis leave room for 25 minted coins
+	schedule := RewardSchedule{Initial: 10, MaxSupply: 1025}
+	assert.Equal(t, uint64(10), schedule.BlockReward(1, 1000))
+	assert.Equal(t, uint64(10), schedule.BlockReward(2, 1000))
+	assert.Equal(t, uint64(5), schedule.BlockReward(3, 1000))
+	assert.Equal(t, uint64(0), schedule.BlockReward(4, 1000))
+	assert.Equal(t, uint64(0), schedule.BlockReward(1, 2000))
+}
+
+func Test_SplitReward(t *testing.T) {
+	a := NewAddress("0x0000000000000000000000000000000000000001")
+	b := NewAddress("0x0000000000000000000000000000000000000002")
+	payouts := SplitReward(10, []RewardShare{{a, 1}, {b, 2}})
+	assert.Equal(t, []Payout{{a, 4}, {b, 6}}, payouts)
+	assert.Nil(t, validateCoinbase(NewCoinbaseTx(7, payouts), 7, 10))
+	assert.Empty(t, SplitReward(0, []RewardShare{{a, 1}}))
+
+	// a reward smaller than the total weight leaves out the shares that round down to nothing
+	c := NewAddress("0x0000000000000000000000000000000000000003")
+	payouts = SplitReward(2, []RewardShare{{a, 3}, {b, 3}, {c, 3}})
+	assert.Equal(t, []Payout{{a, 2}}, payouts)
+	assert.Nil(t, validateCoinbase(NewCoinbaseTx(7, payouts), 7, 2))
+	payouts = SplitReward(3, []RewardShare{{a, 1}, {b, 5}})
+	assert.Equal(t, []Payout{{a, 1}, {b, 2}}, payouts)
+	assert.Nil(t, validateCoinbase(NewCoinbaseTx(7, payouts), 7, 3))
+
+	// weights don't overflow while splitting
+	max := ^uint64(0)
+	assert.Equal(t, []Payout{{a, 5}, {b, 5}}, SplitReward(10, []RewardShare{{a, max}, {b, max}}))
+	assert.NotNil(t, ValidateRewardShares([]RewardShare{{a, max}, {b, 1}}))
+	assert.NotNil(t, ValidateRewardShares([]RewardShare{{a, 0}}))
+	assert.Nil(t, ValidateRewardShares([]RewardShare{{a, max - 2}, {b, 1}}))
+}
+
+func Test_ApplyBlockBody_Coinbase(t *testing.T) {
+	miner := NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	other := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	newState := func() *State {
+		return &State{
+			Catalog:       make(map[common.Address]CurrentNodeState),
+			Account2Nonce: make(map[common.Address]uint),
+			genesis:       Genesis{Reward: &RewardSchedule{Initial: 10}},
+		}
+	}
+
+	s := newState()
+	coinbase := NewCoinbaseTx(3, SplitReward(10, []RewardShare{{miner, 1}, {other, 1}}))
+	assert.Nil(t, applyBlockBody(3, miner, []SignedTx{coinbase}, s))
+	assert.Equal(t, uint64(5), s.Catalog[miner].Balance)
+	assert.Equal(t, uint64(5), s.Catalog[other].Balance)
+
+	// a block must start with a coinbase that pays out exactly its reward
+	assert.NotNil(t, applyBlockBody(3, miner, []SignedTx{}, newState()))
+	assert.NotNil(t, applyBlockBody(3, miner, []SignedTx{NewCoinbaseTx(3, []Payout{{miner, 11}})}, newState()))
+	assert.NotNil(t, applyBlockBody(4, miner, []SignedTx{coinbase}, newState()))
+	assert.NotNil(t, applyBlockBody(3, miner, []SignedTx{coinbase, coinbase}, newState()))
+
+	// without a reward schedule the miner is credited implicitly
+	legacy := newState()
+	legacy.genesis.Reward = nil
+	assert.Nil(t, applyBlockBody(3, miner, []SignedTx{}, legacy))
+	assert.Equal(t, BlockReward, legacy.Catalog[miner].Balance)
+}
+
+func Test_ApplyBlockBody_Fees(t *testing.T) {
+	miner := NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	author, signFn := newTestSigner(t)
+	s := &State{
+		Catalog:       map[common.Address]CurrentNodeState{author: {Balance: 10 * CoinUnit}},
+		Account2Nonce: make(map[common.Address]uint),
+		genesis:       Genesis{Reward: &RewardSchedule{Initial: 10}},
+	}
+	coinbase := NewCoinbaseTx(1, []Payout{{miner, 10}})
+	tx := signTestTx(t, NewTx(author, "topic", 1).WithFee(3), signFn)
+	assert.Nil(t, applyBlockBody(1, miner, []SignedTx{coinbase, tx}, s))
+	// the channel is paid for and the fee goes to the miner
+	assert.Equal(t, 9*CoinUnit-3, s.Catalog[author].Balance)
+	assert.Equal(t, uint64(13), s.Catalog[miner].Balance)
+
+	// the author must be able to pay the fee on top of the tx's value
+	broke := signTestTx(t, NewTx(author, "broke", 2).WithFee(9*CoinUnit), signFn)
+	assert.NotNil(t, applyBlockBody(2, miner, []SignedTx{NewCoinbaseTx(2, []Payout{{miner, 10}}), broke}, s))
+}
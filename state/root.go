@@ -0,0 +1,69 @@
+package state
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// the committed part of an account, hashed into a leaf of the state tree
+type accountLeaf struct {
+	Address       common.Address `json:"address"`
+	Balance       uint64         `json:"balance"`
+	OwnedChannels [][]byte       `json:"channels"`
+	Nonce         uint           `json:"nonce"`
+}
+
+/*
+ Compute the state root: the root of a Merkle tree over every account in
+ the catalog (and every account with a nonce), ordered by address.
+ Pending balances are local to the node and are not committed to.
+*/
+func (s *State) StateRoot() (Hash, error) {
+	accounts := make([]common.Address, 0, len(s.Catalog))
+	seen := make(map[common.Address]bool)
+	for account := range s.Catalog {
+		accounts = append(accounts, account)
+		seen[account] = true
+	}
+	for account := range s.Account2Nonce {
+		if !seen[account] {
+			accounts = append(accounts, account)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i][:], accounts[j][:]) < 0
+	})
+
+	leaves := make([]Hash, len(accounts))
+	for i, account := range accounts {
+		leafJSON, err := json.Marshal(accountLeaf{
+			account,
+			s.Catalog[account].Balance,
+			s.Catalog[account].OwnedChannels,
+			s.Account2Nonce[account],
+		})
+		if err != nil {
+			return Hash{}, err
+		}
+		leaves[i] = sha256.Sum256(leafJSON)
+	}
+	return MerkleRoot(leaves), nil
+}
+
+/*
+ Compute the state root that results from applying the txs and the block reward
+ for 'miner' on top of the current state, without modifying it
+*/
+func (s *State) NextStateRoot(miner common.Address, txs []SignedTx) (Hash, error) {
+	s.mu.Lock()
+	pendingState := s.copy()
+	s.mu.Unlock()
+	if err := applyBlockBody(pendingState.nextBlockNumber(), miner, txs, &pendingState); err != nil {
+		return Hash{}, err
+	}
+	return pendingState.StateRoot()
+}
@@ -0,0 +1,84 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MerkleRoot_Empty(t *testing.T) {
+	assert.Equal(t, Hash{}, MerkleRoot(nil))
+}
+
+func Test_MerkleRoot_OddLeaves(t *testing.T) {
+	a, b, c := Hash{1}, Hash{2}, Hash{3}
+	expected := hashMerkleNode(hashMerkleNode(hashMerkleLeaf(a), hashMerkleLeaf(b)), hashMerkleLeaf(c))
+	assert.Equal(t, expected, MerkleRoot([]Hash{a, b, c}))
+}
+
+func Test_MerkleRoot_DuplicatedLastLeaf(t *testing.T) {
+	a, b, c := Hash{1}, Hash{2}, Hash{3}
+	assert.NotEqual(t, MerkleRoot([]Hash{a, b, c}), MerkleRoot([]Hash{a, b, c, c}))
+}
+
+func Test_MerkleRoot_InnerNodeIsNotALeaf(t *testing.T) {
+	a, b := Hash{1}, Hash{2}
+	inner := hashMerkleNode(hashMerkleLeaf(a), hashMerkleLeaf(b))
+	assert.NotEqual(t, MerkleRoot([]Hash{a, b}), MerkleRoot([]Hash{inner}))
+}
+
+func Test_StateRoot_IgnoresPendingState(t *testing.T) {
+	account := NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	s := NewMemoryState(map[common.Address]CurrentNodeState{account: {Balance: 10 * CoinUnit}}, map[common.Address]uint{account: 1})
+	root, err := s.StateRoot()
+	assert.Nil(t, err)
+
+	assert.Nil(t, s.NewPendingState().ApplyTx(NewSignedTx(NewTx(account, "pending", 2), nil)))
+	pendingRoot, err := s.StateRoot()
+	assert.Nil(t, err)
+	assert.Equal(t, root, pendingRoot)
+
+	s.Catalog[account] = CurrentNodeState{Balance: 9 * CoinUnit}
+	changedRoot, err := s.StateRoot()
+	assert.Nil(t, err)
+	assert.NotEqual(t, root, changedRoot)
+}
+
+func Test_MerkleProof_Verify(t *testing.T) {
+	for count := 1; count <= 9; count++ {
+		leaves := make([]Hash, count)
+		for i := range leaves {
+			leaves[i] = Hash{byte(i + 1)}
+		}
+		root := MerkleRoot(leaves)
+		for i, leaf := range leaves {
+			assert.True(t, VerifyMerkleProof(root, leaf, i, count, MerkleProof(leaves, i)), "leaf %d of %d", i, count)
+		}
+	}
+	leaves := []Hash{{1}, {2}, {3}, {4}, {5}}
+	root := MerkleRoot(leaves)
+	assert.False(t, VerifyMerkleProof(root, Hash{9}, 0, 5, MerkleProof(leaves, 0)))
+	assert.False(t, VerifyMerkleProof(root, leaves[1], 0, 5, MerkleProof(leaves, 1)))
+	assert.False(t, VerifyMerkleProof(root, leaves[4], 5, 6, MerkleProof(leaves, 4)))
+	// an inner node can't be proven as a leaf
+	inner := hashMerkleNode(hashMerkleLeaf(leaves[0]), hashMerkleLeaf(leaves[1]))
+	assert.False(t, VerifyMerkleProof(root, inner, 0, 3, MerkleProof(leaves, 0)[1:]))
+}
+
+func Test_VerifyTxProof(t *testing.T) {
+	author := NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	txs := []SignedTx{
+		NewSignedTx(NewTx(author, "first", 1), nil),
+		NewSignedTx(NewTx(author, "second", 2), nil),
+		NewSignedTx(NewTx(author, "third", 3), nil),
+	}
+	txRoot, err := TxRoot(txs)
+	assert.Nil(t, err)
+	header := BlockHeader{TxRoot: txRoot}
+
+	leaves, err := txHashes(txs)
+	assert.Nil(t, err)
+	assert.True(t, VerifyTxProof(header, leaves[2], 2, len(txs), MerkleProof(leaves, 2)))
+	assert.False(t, VerifyTxProof(BlockHeader{}, leaves[2], 2, len(txs), MerkleProof(leaves, 2)))
+}
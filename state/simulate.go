@@ -0,0 +1,45 @@
+package state
+
+// DroppedTx is a candidate tx that was left out of a block, and why
+type DroppedTx struct {
+	TX     SignedTx
+	Reason error
+	// the tx can never apply, e.g. it is forged. Otherwise it failed on the state it was
+	// applied to, e.g. on its nonce or balance, and may apply to a later block.
+	Permanent bool
+}
+
+/*
+ Simulate the txs of a block being built on a copy of the state, in the order the block
+ would apply them, and drop the ones that fail. The coinbase tx, if the chain has a reward
+ schedule, comes first and is always kept. The txs that apply are returned in the order they
+ are applied, so the block can hold them in that order, and the dropped ones with the reason
+ they failed.
+*/
+func (s *State) SimulateTXs(txs []SignedTx) ([]SignedTx, []DroppedTx) {
+	s.mu.Lock()
+	pendingState := s.copy()
+	s.mu.Unlock()
+	candidates := txs
+	valid := make([]SignedTx, 0, len(txs))
+	if s.genesis.Reward != nil && len(txs) > 0 && txs[0].TxType() == TxTypeCoinbase {
+		applyCoinbase(*txs[0].Coinbase, &pendingState)
+		valid = append(valid, txs[0])
+		candidates = txs[1:]
+	}
+
+	number := pendingState.nextBlockNumber()
+	dropped := make([]DroppedTx, 0)
+	for _, i := range applyOrder(candidates) {
+		if err := verifyTx(number, candidates[i], &pendingState); err != nil {
+			dropped = append(dropped, DroppedTx{candidates[i], err, true})
+			continue
+		}
+		if err := applyTx(number, candidates[i], &pendingState); err != nil {
+			dropped = append(dropped, DroppedTx{candidates[i], err, false})
+			continue
+		}
+		valid = append(valid, candidates[i])
+	}
+	return valid, dropped
+}
@@ -0,0 +1,60 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SimulateTXs(t *testing.T) {
+	from, signFn := newTestSigner(t)
+	_, forgerSignFn := newTestSigner(t)
+	to := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	s := NewMemoryState(map[common.Address]CurrentNodeState{from: {Balance: 10}}, map[common.Address]uint{from: 1})
+
+	stale := signTestTx(t, NewTransferTx(from, to, 1, 1), signFn)
+	paid := signTestTx(t, NewTransferTx(from, to, 4, 2), signFn)
+	unpaid := signTestTx(t, NewTransferTx(from, to, 7, 3), signFn)
+	next := signTestTx(t, NewTransferTx(from, to, 6, 3), signFn)
+	forged := signTestTx(t, NewTransferTx(from, to, 1, 4), forgerSignFn)
+	valid, dropped := s.SimulateTXs([]SignedTx{stale, paid, unpaid, next, forged})
+
+	assert.Equal(t, []SignedTx{paid, next}, valid)
+	assert.Len(t, dropped, 3)
+	for i, tx := range []SignedTx{stale, unpaid, forged} {
+		assert.Equal(t, tx, dropped[i].TX)
+		assert.NotNil(t, dropped[i].Reason)
+	}
+	// only the forged tx can never apply, the others failed on the nonce and balance
+	assert.False(t, dropped[0].Permanent)
+	assert.False(t, dropped[1].Permanent)
+	assert.True(t, dropped[2].Permanent)
+	// the simulation doesn't change the state
+	assert.Equal(t, uint64(10), s.Catalog[from].Balance)
+	assert.Equal(t, uint(1), s.Account2Nonce[from])
+}
+
+func Test_SimulateTXs_NonceOrder(t *testing.T) {
+	from, signFn := newTestSigner(t)
+	other, otherSignFn := newTestSigner(t)
+	to := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	s := NewMemoryState(map[common.Address]CurrentNodeState{from: {Balance: 10}, other: {Balance: 10}}, make(map[common.Address]uint))
+
+	// a tx that replaced the account's first tx is signed after its next tx
+	next := NewTransferTx(from, to, 1, 2)
+	next.Time = 10
+	otherTX := NewTransferTx(other, to, 1, 1)
+	otherTX.Time = 11
+	replacement := NewTransferTx(from, to, 1, 1).WithFee(1)
+	replacement.Time = 12
+	txs := []SignedTx{
+		signTestTx(t, next, signFn),
+		signTestTx(t, otherTX, otherSignFn),
+		signTestTx(t, replacement, signFn),
+	}
+	valid, dropped := s.SimulateTXs(txs)
+	assert.Empty(t, dropped)
+	// the account's txs take its slots in nonce order
+	assert.Equal(t, []SignedTx{txs[2], txs[1], txs[0]}, valid)
+}
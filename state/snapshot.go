@@ -0,0 +1,138 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+// a snapshot of the state is written to disk every SnapshotInterval blocks
+const SnapshotInterval = 100
+
+// the version of the snapshot format, snapshots of another version are ignored and the chain is replayed
+const SnapshotVersion = 2
+
+// Snapshot is the state as of the block 'LatestBlockHash'
+type Snapshot struct {
+	Version         uint64                              `json:"version"`
+	Catalog         map[common.Address]CurrentNodeState `json:"catalog"`
+	Account2Nonce   map[common.Address]uint             `json:"account_nonces"`
+	LatestBlock     Block                               `json:"latest_block"`
+	LatestBlockHash Hash                                `json:"latest_block_hash"`
+	Checksum        Hash                                `json:"checksum"`
+}
+
+/*
+ Checksum over the snapshot contents (excluding the checksum itself)
+*/
+func (snap Snapshot) checksum() (Hash, error) {
+	snap.Checksum = Hash{}
+	snapJSON, err := json.Marshal(snap)
+	if err != nil {
+		return Hash{}, err
+	}
+	return sha256.Sum256(snapJSON), nil
+}
+
+/*
+ Take a snapshot of the current state
+*/
+func (s *State) snapshot() (Snapshot, error) {
+	c := s.copy()
+	snap := Snapshot{
+		Version:         SnapshotVersion,
+		Catalog:         c.Catalog,
+		Account2Nonce:   c.Account2Nonce,
+		LatestBlock:     s.latestBlock,
+		LatestBlockHash: s.latestBlockHash,
+	}
+	checksum, err := snap.checksum()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snap.Checksum = checksum
+	return snap, nil
+}
+
+/*
+ Write a snapshot of the current state to the datadir.
+ The snapshot is written to a temp file and renamed so a crash never leaves a partial snapshot behind.
+*/
+func (s *State) WriteSnapshot() error {
+	if s.latestBlockHash.IsEmpty() {
+		return nil
+	}
+	snap, err := s.snapshot()
+	if err != nil {
+		return err
+	}
+	snapJSON, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	tmpPath := getSnapshotFilePath(s.datadir, true)
+	if err := writeFileSync(tmpPath, snapJSON); err != nil {
+		return err
+	}
+	if err := Rename(tmpPath, getSnapshotFilePath(s.datadir, false)); err != nil {
+		return err
+	}
+	logrus.Infof("Wrote state snapshot at block %d\n", s.latestBlock.Header.Number)
+	return nil
+}
+
+/*
+ Load the snapshot from the datadir, if there is one.
+ The snapshot is only returned if its checksum is intact, the block it was taken at
+ is still the block at that height in the block store and its accounts hash to
+ that block's state root.
+*/
+func loadSnapshot(datadir string, store BlockStore) (Snapshot, bool, error) {
+	path := getSnapshotFilePath(datadir, false)
+	if !fileExists(path) {
+		return Snapshot{}, false, nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(content, &snap); err != nil {
+		return Snapshot{}, false, fmt.Errorf("snapshot is corrupt: %s", err)
+	}
+	if snap.Version != SnapshotVersion {
+		return Snapshot{}, false, fmt.Errorf("snapshot version must be '%d' not '%d'", SnapshotVersion, snap.Version)
+	}
+	checksum, err := snap.checksum()
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	if checksum != snap.Checksum {
+		return Snapshot{}, false, fmt.Errorf("snapshot checksum mismatch")
+	}
+	blockHash, err := snap.LatestBlock.Hash()
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	if blockHash != snap.LatestBlockHash {
+		return Snapshot{}, false, fmt.Errorf("snapshot latest block does not match its hash")
+	}
+	if canonical, ok := store.HashAt(snap.LatestBlock.Header.Number); !ok || canonical != snap.LatestBlockHash {
+		return Snapshot{}, false, fmt.Errorf("snapshot block '%x' is not part of the chain", snap.LatestBlockHash)
+	}
+	// the checksum only catches accidents, the state root is what the chain agreed on
+	snapState := State{Catalog: snap.Catalog, Account2Nonce: snap.Account2Nonce}
+	stateRoot, err := snapState.StateRoot()
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	if stateRoot != snap.LatestBlock.Header.StateRoot {
+		return Snapshot{}, false, fmt.Errorf("snapshot state root must be '%x' not '%x'",
+			snap.LatestBlock.Header.StateRoot, stateRoot)
+	}
+	return snap, true, nil
+}
@@ -0,0 +1,77 @@
+package state
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Snapshot_WriteAndLoad(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "snapshot_test")
+	assert.Nil(t, err)
+	defer RemoveDir(datadir)
+	assert.Nil(t, os.MkdirAll(getDatabaseDirPath(datadir), os.ModePerm))
+
+	store, err := NewFileBlockStore(getBlocksDbFilePath(datadir, false))
+	assert.Nil(t, err)
+	defer store.Close()
+	miner := NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	s := &State{
+		Catalog:       map[common.Address]CurrentNodeState{miner: {Balance: 10}},
+		Account2Nonce: map[common.Address]uint{miner: 3},
+		store:         store,
+		datadir:       datadir,
+	}
+	stateRoot, err := s.StateRoot()
+	assert.Nil(t, err)
+	block := NewBlock(Hash{}, stateRoot, Hash{}, 1, 1, DefaultDifficulty, nil, 1, miner, 1)
+	s.latestBlock = block
+	s.latestBlockHash, err = store.Put(block)
+	assert.Nil(t, err)
+	assert.Nil(t, s.WriteSnapshot())
+
+	snap, ok, err := loadSnapshot(datadir, store)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, s.latestBlockHash, snap.LatestBlockHash)
+	assert.Equal(t, uint64(10), snap.Catalog[miner].Balance)
+	assert.Equal(t, uint(3), snap.Account2Nonce[miner])
+
+	// a snapshot that doesn't hash to the block's state root is rejected, even with a valid checksum
+	s.Catalog[miner] = CurrentNodeState{Balance: 1000}
+	assert.Nil(t, s.WriteSnapshot())
+	_, ok, err = loadSnapshot(datadir, store)
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+}
+
+func Test_Snapshot_RejectsBlockNotInChain(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "snapshot_test")
+	assert.Nil(t, err)
+	defer RemoveDir(datadir)
+	assert.Nil(t, os.MkdirAll(getDatabaseDirPath(datadir), os.ModePerm))
+
+	store, err := NewFileBlockStore(getBlocksDbFilePath(datadir, false))
+	assert.Nil(t, err)
+	defer store.Close()
+	block := NewBlock(Hash{}, Hash{}, Hash{}, 1, 1, DefaultDifficulty, nil, 1, NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD"), 1)
+	blockHash, err := block.Hash()
+	assert.Nil(t, err)
+
+	s := &State{
+		Catalog:         map[common.Address]CurrentNodeState{},
+		Account2Nonce:   map[common.Address]uint{},
+		latestBlock:     block,
+		latestBlockHash: blockHash,
+		store:           store,
+		datadir:         datadir,
+	}
+	assert.Nil(t, s.WriteSnapshot())
+
+	_, ok, err := loadSnapshot(datadir, store)
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+}
@@ -1,39 +1,48 @@
 package state
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
-	"os"
 	"reflect"
 	"sort"
+	"sync"
 
 	"github.com/driemworks/mercury-blockchain/core"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/raphamorim/go-rainbow"
 )
 
-const BlockReward = float32(10)
+// amounts are integers in the base unit, one coin is CoinUnit base units
+const CoinUnit = uint64(100000000)
+
+// the reward of a block on chains without a reward schedule
+const BlockReward = 10 * CoinUnit
+
+// the cost of creating a topic
+const TopicCost = 1 * CoinUnit
 
 type CurrentNodeState struct {
-	OwnedChannels  [][]byte `json:"channels"`
-	Balance        float32  `json:"balance"`
-	PendingBalance float32  `json:"pending_balance"`
+	OwnedChannels [][]byte `json:"channels"`
+	Balance       uint64   `json:"balance"`
 }
 
 type State struct {
-	Subscriptions        map[string]chan core.MessageTransport
-	Catalog              map[common.Address]CurrentNodeState
-	Account2Nonce        map[common.Address]uint
-	PendingAccount2Nonce map[common.Address]uint
-	txMempool            []Tx
-	latestBlock          Block
-	latestBlockHash      Hash
-	dbFile               *os.File
-	datadir              string
-	hasGenesisBlock      bool
+	Subscriptions   map[string]chan core.MessageTransport
+	Catalog         map[common.Address]CurrentNodeState
+	Account2Nonce   map[common.Address]uint
+	txMempool       []Tx
+	latestBlock     Block
+	latestBlockHash Hash
+	store           BlockStore
+	receipts        *receiptStore
+	tree            *blockTree
+	engine          Engine
+	undo            map[Hash]blockUndo
+	genesis         Genesis
+	mu              *sync.Mutex
+	datadir         string
+	hasGenesisBlock bool
 }
 
 /*
@@ -45,88 +54,174 @@ func NewStateFromDisk(datadir string) (*State, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = migrateGenesis(getGenesisJsonFilePath(datadir)); err != nil {
+		return nil, fmt.Errorf("couldn't migrate genesis. %s", err.Error())
+	}
 	gen, err := loadGenesis(getGenesisJsonFilePath(datadir))
 	if err != nil {
 		return nil, err
 	}
+	if err = checkGenesisHash(datadir, gen); err != nil {
+		return nil, err
+	}
 	// load the manifest -> consider refactoring name..
 	// using manifest as var and Manifest as type, but they are not the same thing
 	manifest := make(map[common.Address]CurrentNodeState)
 	for account, s := range gen.State {
 		// subscriptions not managed via the blockchain, so shall be nil for now...
 		// this will change
-		manifest[account] = CurrentNodeState{s.OwnedChannels, s.Balance, s.PendingBalance}
+		manifest[account] = CurrentNodeState{s.OwnedChannels, s.Balance}
 	}
 
-	blockDbFile, err := os.OpenFile(getBlocksDbFilePath(datadir, false), os.O_APPEND|os.O_RDWR, 0600)
+	engine, err := NewEngine(gen)
 	if err != nil {
 		return nil, err
 	}
-	scanner := bufio.NewScanner(blockDbFile)
-	account2Nonce := make(map[common.Address]uint)
-	pendingAccount2Nonce := make(map[common.Address]uint)
-	state := &State{make(map[string]chan core.MessageTransport, 0), manifest, account2Nonce, pendingAccount2Nonce, make([]Tx, 0), Block{}, Hash{}, blockDbFile, datadir, true}
-	for scanner.Scan() {
-		// handle scanner error
-		if err := scanner.Err(); err != nil {
-			return nil, err
-		}
-		blockFsJSON := scanner.Bytes()
-		if len(blockFsJSON) == 0 {
-			break
+	store, err := NewFileBlockStore(getBlocksDbFilePath(datadir, false))
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := newReceiptStore(getReceiptsDbFilePath(datadir), MaxFailedReceipts)
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	state := &State{
+		Subscriptions:   make(map[string]chan core.MessageTransport, 0),
+		Catalog:         make(map[common.Address]CurrentNodeState),
+		Account2Nonce:   make(map[common.Address]uint),
+		txMempool:       make([]Tx, 0),
+		store:           store,
+		receipts:        receipts,
+		tree:            newBlockTree(),
+		engine:          engine,
+		undo:            make(map[Hash]blockUndo),
+		genesis:         gen,
+		mu:              &sync.Mutex{},
+		datadir:         datadir,
+		hasGenesisBlock: true,
+	}
+	for account, value := range manifest {
+		state.Catalog[account] = value
+	}
+	// choose the canonical chain before loading the snapshot, which must be part of it
+	if err = state.loadBlockTree(); err != nil {
+		store.Close()
+		receipts.close()
+		return nil, err
+	}
+	// start from the latest snapshot, if any, and only replay the blocks after it
+	snap, ok, err := loadSnapshot(datadir, store)
+	if err != nil {
+		logrus.Warnf("Ignoring state snapshot, replaying the chain from genesis: %s\n", err)
+	} else if ok {
+		state.Catalog = snap.Catalog
+		state.Account2Nonce = snap.Account2Nonce
+		state.latestBlock = snap.LatestBlock
+		state.latestBlockHash = snap.LatestBlockHash
+		logrus.Infof("Loaded state snapshot at block %d\n", snap.LatestBlock.Header.Number)
+	}
+	if err = state.replayFrom(state.NextBlockNumber()); err != nil {
+		store.Close()
+		receipts.close()
+		return nil, err
+	}
+	return state, nil
+}
+
+/*
+ Apply the blocks in the store from the given height onwards to the state
+*/
+func (s *State) replayFrom(height uint64) error {
+	for ; ; height++ {
+		blockHash, ok := s.store.HashAt(height)
+		if !ok {
+			if height == 0 {
+				continue
+			}
+			return nil
 		}
-		var blockFs BlockFS
-		if err := json.Unmarshal(blockFsJSON, &blockFs); err != nil {
-			return nil, err
+		block, _, err := s.store.Get(blockHash)
+		if err != nil {
+			return err
 		}
-		if err = ApplyBlock(blockFs.Value, state); err != nil {
-			return nil, err
+		if err := ApplyBlock(block, s); err != nil {
+			return err
 		}
-		state.latestBlock = blockFs.Value
-		state.latestBlockHash = blockFs.Key
+		s.latestBlock = block
+		s.latestBlockHash = blockHash
 	}
-	return state, nil
 }
 
-func (s *State) AddBlock(b Block) (*State, Hash, error) {
-	pendingState := s.copy()
-	latestBlock := s.latestBlock
-	if s.hasGenesisBlock && b.Header.Number < latestBlock.Header.Number+1 {
-		return nil, Hash{}, nil
-	}
-	err := ApplyBlock(b, &pendingState)
+/*
+ Add the block to the block tree. If it extends the canonical chain it is applied
+ to the state and persisted, if it is on a side branch it is kept in memory until
+ its branch has more accumulated work than the canonical chain, at which point
+ the chain is reorganised onto that branch.
+*/
+func (s *State) AddBlock(b Block) (ChainUpdate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blockHash, err := b.Hash()
 	if err != nil {
-		return nil, Hash{}, err
+		return ChainUpdate{}, err
+	}
+	if _, known := s.tree.get(blockHash); known {
+		return ChainUpdate{Hash: blockHash}, nil
+	}
+	parent, ok := s.tree.get(b.Header.Parent)
+	if !ok {
+		return ChainUpdate{}, fmt.Errorf("parent block '%x' of block '%x' is unknown", b.Header.Parent, blockHash)
+	}
+	if b.Header.Number != parent.header.Number+1 {
+		return ChainUpdate{}, fmt.Errorf("block number must be '%d' not '%d'", parent.header.Number+1, b.Header.Number)
+	}
+	if err := s.verifyBlock(b, blockHash); err != nil {
+		return ChainUpdate{}, err
 	}
 
-	blockHash, err := b.Hash()
+	if parent.hash != s.latestBlockHash {
+		node := s.tree.add(blockHash, b.Header, s.engine.Work(b.Header), &b)
+		if node.totalWork.Cmp(s.tree.tip.totalWork) <= 0 {
+			logrus.Infof("Block '%s' at height %d is on a side branch\n", blockHash.Hex(), b.Header.Number)
+			return ChainUpdate{Hash: blockHash}, nil
+		}
+		return s.reorg(node)
+	}
+
+	pendingState := s.copy()
+	err = ApplyBlock(b, &pendingState)
 	if err != nil {
-		return nil, Hash{}, err
+		return ChainUpdate{}, err
 	}
 
-	blockFs := BlockFS{blockHash, b}
+	_, err = s.store.Put(b)
+	if err != nil {
+		return ChainUpdate{}, err
+	}
 
-	blockFsJSON, err := json.Marshal(blockFs)
+	blockFsJSON, err := json.Marshal(BlockFS{blockHash, b})
 	if err != nil {
-		return nil, Hash{}, err
+		return ChainUpdate{}, err
 	}
 
 	prettyJSON, err := core.PrettyPrintJSON(blockFsJSON)
-	logrus.Infof("Persisting new Block to disk:\n")
+	logrus.Infof("Persisted new Block to disk:\n")
 	logrus.Infof("\t%s\n", &prettyJSON)
 
-	_, err = s.dbFile.Write(append(blockFsJSON, '\n'))
-	if err != nil {
-		return nil, Hash{}, err
-	}
+	s.tree.tip = s.tree.add(blockHash, b.Header, s.engine.Work(b.Header), nil)
+	s.undo[blockHash] = newBlockUndo(s, &pendingState)
+	pendingState.latestBlock = b
+	pendingState.latestBlockHash = blockHash
+	s.commit(pendingState)
 
-	s.Account2Nonce = pendingState.Account2Nonce
-	s.Catalog = pendingState.Catalog
-	s.latestBlockHash = blockHash
-	s.latestBlock = b
-	s.hasGenesisBlock = true
+	if b.Header.Number%SnapshotInterval == 0 {
+		if err := s.WriteSnapshot(); err != nil {
+			logrus.Errorln("failed to write state snapshot: ", err)
+		}
+	}
 
-	return nil, blockHash, nil
+	return ChainUpdate{blockHash, []Block{b}, nil}, nil
 }
 
 func ApplyBlock(b Block, s *State) error {
@@ -141,38 +236,108 @@ func ApplyBlock(b Block, s *State) error {
 	} else if s.hasGenesisBlock && s.latestBlock.Header.Number > 0 && !reflect.DeepEqual(b.Header.Parent, s.latestBlockHash) {
 		return fmt.Errorf("next block parent hash must be '%x' not '%x'", s.latestBlockHash, b.Header.Parent)
 	}
-	if !IsBlockHashValid(hash) {
-		return fmt.Errorf(rainbow.Red("Invalid block hash %x"), hash)
+	if err = s.verifyBlock(b, hash); err != nil {
+		return err
+	}
+	err = applyBlockBody(b.Header.Number, b.Header.Miner, b.TXs, s)
+	if err != nil {
+		return err
 	}
-	err = applyTXs(b.TXs, s)
+	stateRoot, err := s.StateRoot()
 	if err != nil {
 		return err
 	}
-	tmp := s.Catalog[b.Header.Miner]
-	tmp.Balance += BlockReward
-	tmp.PendingBalance += BlockReward
-	s.Catalog[b.Header.Miner] = tmp
+	if stateRoot != b.Header.StateRoot {
+		return fmt.Errorf("block state root must be '%x' not '%x'", stateRoot, b.Header.StateRoot)
+	}
 
 	return nil
 }
 
+/*
+ Verify the parts of the block that don't depend on the account state:
+ its consensus fields and seal, and its tx root
+*/
+func (s *State) verifyBlock(b Block, hash Hash) error {
+	if err := s.engine.VerifyHeader(s, b.Header, hash); err != nil {
+		return err
+	}
+	txRoot, err := TxRoot(b.TXs)
+	if err != nil {
+		return err
+	}
+	if txRoot != b.Header.TxRoot {
+		return fmt.Errorf("block tx root must be '%x' not '%x'", txRoot, b.Header.TxRoot)
+	}
+	return nil
+}
+
+/*
+ Apply the block's txs and reward the miner, who also earns the fees of the txs
+*/
+func applyBlockBody(number uint64, miner common.Address, txs []SignedTx, s *State) error {
+	if s.genesis.Reward == nil {
+		// chains without a reward schedule credit the miner implicitly
+		err := applyTXs(number, txs, s)
+		if err != nil {
+			return err
+		}
+		tmp := s.Catalog[miner]
+		tmp.Balance += BlockReward
+		s.Catalog[miner] = tmp
+		creditFees(miner, txs, s)
+		return nil
+	}
+	if len(txs) == 0 {
+		return fmt.Errorf("the first tx of a block must be its coinbase")
+	}
+	reward := s.genesis.Reward.BlockReward(number, s.genesis.Supply())
+	if err := validateCoinbase(txs[0], number, reward); err != nil {
+		return err
+	}
+	applyCoinbase(*txs[0].Coinbase, s)
+	if err := applyTXs(number, txs[1:], s); err != nil {
+		return err
+	}
+	creditFees(miner, txs[1:], s)
+	return nil
+}
+
+/*
+ Pay the fees of the block's txs to its miner
+*/
+func creditFees(miner common.Address, txs []SignedTx, s *State) {
+	fees := uint64(0)
+	for _, tx := range txs {
+		fees = saturatingAdd(fees, tx.Fee)
+	}
+	if fees == 0 {
+		return
+	}
+	account := s.Catalog[miner]
+	account.Balance = saturatingAdd(account.Balance, fees)
+	s.Catalog[miner] = account
+}
+
 func (s *State) NextBlockNumber() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nextBlockNumber()
+}
+
+func (s *State) nextBlockNumber() uint64 {
 	if !s.hasGenesisBlock {
 		return uint64(0)
 	}
-	return s.LatestBlock().Header.Number + 1
+	return s.latestBlock.Header.Number + 1
 }
 
 /*
 *
  */
-func applyTXs(txs []SignedTx, s *State) error {
-	sort.Slice(txs, func(i, j int) bool {
-		return txs[i].Time < txs[j].Time
-	})
-
-	for _, tx := range txs {
-		err := applyTx(tx, s)
+func applyTXs(number uint64, txs []SignedTx, s *State) error {
+	for _, i := range applyOrder(txs) {
+		err := applyTx(number, txs[i], s)
 		if err != nil {
 			return err
 		}
@@ -182,16 +347,49 @@ func applyTXs(txs []SignedTx, s *State) error {
 }
 
 /*
-* apply the transaction to the current state
+* The indexes of the txs in the order they are applied: txs are applied by time, but each account's
+* txs are applied in nonce order, in the slots its txs take. A tx that replaced another is signed after
+* the account's next tx and still comes first. The txs aren't sorted in place, that would reorder
+* (and so rehash) the block's txs.
  */
-func applyTx(tx SignedTx, s *State) error {
-	ok, err := tx.IsAuthentic()
-	if err != nil {
-		return err
+func applyOrder(txs []SignedTx) []int {
+	order := make([]int, len(txs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := txs[order[i]], txs[order[j]]
+		if a.Time != b.Time {
+			return a.Time < b.Time
+		}
+		return a.Nonce < b.Nonce
+	})
+	slots := make(map[common.Address][]int)
+	for slot, i := range order {
+		slots[txs[i].Author] = append(slots[txs[i].Author], slot)
 	}
+	byNonce := make([]int, len(order))
+	for _, accountSlots := range slots {
+		accountTXs := make([]int, len(accountSlots))
+		for k, slot := range accountSlots {
+			accountTXs[k] = order[slot]
+		}
+		sort.SliceStable(accountTXs, func(i, j int) bool {
+			return txs[accountTXs[i]].Nonce < txs[accountTXs[j]].Nonce
+		})
+		for k, slot := range accountSlots {
+			byNonce[slot] = accountTXs[k]
+		}
+	}
+	return byNonce
+}
 
-	if !ok {
-		return fmt.Errorf("bad Tx. Sender '%s' is forged", tx.Author.String())
+/*
+* apply the transaction of the block at height 'number' to the current state
+ */
+func applyTx(number uint64, tx SignedTx, s *State) error {
+	if err := verifyTx(number, tx, s); err != nil {
+		return err
 	}
 	expectedNonce := s.Account2Nonce[tx.Author] + 1
 	if tx.Nonce != expectedNonce {
@@ -199,10 +397,62 @@ func applyTx(tx SignedTx, s *State) error {
 		return fmt.Errorf("bad Tx. next nonce must be '%d', not '%d'", expectedNonce, tx.Nonce)
 	}
 
-	// if s.Manifest[tx.From].PendingBalance < 1 {
-	// 	return fmt.Errorf("bad Tx. You have no remaining balance")
-	// }
+	if s.Catalog[tx.Author].Balance < tx.Cost() {
+		return fmt.Errorf("bad Tx. '%s' has a balance of %d but the tx costs %d",
+			tx.Author.Hex(), s.Catalog[tx.Author].Balance, tx.Cost())
+	}
 
+	// the fee is paid to the miner once the whole block is applied
+	author := s.Catalog[tx.Author]
+	author.Balance -= tx.Fee
+	s.Catalog[tx.Author] = author
+
+	var err error
+	switch tx.TxType() {
+	case TxTypeTopic:
+		err = applyTopic(tx, s)
+	case TxTypeTransfer:
+		err = applyTransfer(tx, s)
+	case TxTypeVote:
+		// votes are tallied by the consensus engine and don't change the accounts
+	}
+	if err != nil {
+		return err
+	}
+	s.Account2Nonce[tx.Author] = tx.Nonce
+	return nil
+}
+
+/*
+* check the parts of the tx that don't depend on the account state: its payload, and that it is
+* signed by its author for this chain
+ */
+func verifyTx(number uint64, tx SignedTx, s *State) error {
+	if err := tx.ValidatePayload(); err != nil {
+		return fmt.Errorf("bad Tx. %s", err.Error())
+	}
+	if tx.TxType() == TxTypeCoinbase {
+		return fmt.Errorf("bad Tx. A coinbase tx must be the first tx of a block")
+	}
+	chainID := s.genesis.ChainID
+	if tx.ChainID == "" && number <= s.genesis.ChainIDFork {
+		// signed before txs were signed for a chain
+		chainID = ""
+	}
+	ok, err := tx.IsAuthentic(chainID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("bad Tx. Sender '%s' is forged", tx.Author.String())
+	}
+	return nil
+}
+
+/*
+* create the channel named by the tx topic, owned by its author
+ */
+func applyTopic(tx SignedTx, s *State) error {
 	h, err := tx.Hash()
 	if err != nil {
 		return fmt.Errorf("bad Tx. Can't calculate tx hash")
@@ -213,11 +463,25 @@ func applyTx(tx SignedTx, s *State) error {
 	}
 	var currentNodeState = s.Catalog[tx.Author]
 	// for now, just assume topic creation only?
-	currentNodeState.OwnedChannels = append(currentNodeState.OwnedChannels, hashText)
-	// TODO: assume for now that topic creation costs 1 coin
-	currentNodeState.Balance = currentNodeState.Balance - 1
+	// copy the channels so the append doesn't write into an array shared with a copy of the state
+	ownedChannels := make([][]byte, len(currentNodeState.OwnedChannels), len(currentNodeState.OwnedChannels)+1)
+	copy(ownedChannels, currentNodeState.OwnedChannels)
+	currentNodeState.OwnedChannels = append(ownedChannels, hashText)
+	currentNodeState.Balance -= tx.Value()
 	s.Catalog[tx.Author] = currentNodeState
-	s.Account2Nonce[tx.Author] = tx.Nonce
+	return nil
+}
+
+/*
+* move the transferred amount from the tx author to the recipient
+ */
+func applyTransfer(tx SignedTx, s *State) error {
+	from := s.Catalog[tx.Author]
+	from.Balance -= tx.Transfer.Amount
+	s.Catalog[tx.Author] = from
+	to := s.Catalog[tx.Transfer.To]
+	to.Balance += tx.Transfer.Amount
+	s.Catalog[tx.Transfer.To] = to
 	return nil
 }
 
@@ -225,13 +489,51 @@ func applyTx(tx SignedTx, s *State) error {
 * Close the connection to the file
  */
 func (s *State) Close() {
-	s.dbFile.Close()
+	if err := s.WriteSnapshot(); err != nil {
+		logrus.Errorln("failed to write state snapshot: ", err)
+	}
+	s.store.Close()
+	if s.receipts != nil {
+		s.receipts.close()
+	}
+}
+
+/*
+ A state held only in memory, without blocks, with the given balances and nonces
+*/
+func NewMemoryState(catalog map[common.Address]CurrentNodeState, nonces map[common.Address]uint) *State {
+	return &State{
+		Catalog:       catalog,
+		Account2Nonce: nonces,
+		mu:            &sync.Mutex{},
+	}
+}
+
+/*
+ The committed balance and channels of the account.
+ Committing a block replaces the state's maps, so they are only read under the lock.
+*/
+func (s *State) Account(account common.Address) CurrentNodeState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Catalog[account]
+}
+
+/*
+ The nonce of the account's latest mined tx
+*/
+func (s *State) Nonce(account common.Address) uint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Account2Nonce[account]
 }
 
 /*
 * Get the latest block hash from the current state
  */
 func (s *State) LatestBlockHash() Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.latestBlockHash
 }
 
@@ -239,16 +541,34 @@ func (s *State) LatestBlockHash() Hash {
 * Get the latest block from the current state
  */
 func (s *State) LatestBlock() Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.latestBlock
 }
 
+/*
+ A copy of the committed state, taken under the lock. A block is built on one copy so its
+ reward, txs and state root all follow the same parent, even if another block is committed meanwhile.
+*/
+func (s *State) Copy() *State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copy := s.copy()
+	copy.mu = &sync.Mutex{}
+	return &copy
+}
+
 /*
 * Copy the state
  */
 func (s *State) copy() State {
 	copy := State{}
 	copy.hasGenesisBlock = s.hasGenesisBlock
-	copy.dbFile = s.dbFile
+	copy.store = s.store
+	copy.receipts = s.receipts
+	copy.tree = s.tree
+	copy.engine = s.engine
+	copy.genesis = s.genesis
 	copy.latestBlock = s.latestBlock
 	copy.latestBlockHash = s.latestBlockHash
 	copy.txMempool = make([]Tx, len(s.txMempool))
@@ -264,41 +584,47 @@ func (s *State) copy() State {
 }
 
 /*
- Get all blocks in 'datadir' whose parent is a child of the block with the given block hash
+ Get the block with the given hash from the block store
 */
-func GetBlocksAfter(blockHash Hash, dataDir string) ([]Block, error) {
-	// open block.db
-	f, err := os.OpenFile(getBlocksDbFilePath(dataDir, false), os.O_RDONLY, 0600)
-	if err != nil {
-		return nil, err
-	}
-	blocks := make([]Block, 0)
-	shouldStartCollecting := false
-	if reflect.DeepEqual(blockHash, Hash{}) {
-		shouldStartCollecting = true
+func (s *State) GetBlock(hash Hash) (Block, bool, error) {
+	if block, ok := s.tree.block(hash); ok {
+		return block, true, nil
 	}
+	return s.store.Get(hash)
+}
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		if err := scanner.Err(); err != nil {
-			return nil, err
-		}
+/*
+ Get the block at the given height from the block store
+*/
+func (s *State) GetBlockByHeight(height uint64) (Block, bool, error) {
+	return s.store.GetByHeight(height)
+}
 
-		var blockFs BlockFS
-		err = json.Unmarshal(scanner.Bytes(), &blockFs)
+/*
+ Get all blocks whose parent is a child of the block with the given block hash
+*/
+func (s *State) GetBlocksAfter(blockHash Hash) ([]Block, error) {
+	latest := s.LatestBlock().Header.Number
+	blocks := make([]Block, 0)
+	from := uint64(0)
+	if !blockHash.IsEmpty() {
+		block, ok, err := s.store.Get(blockHash)
 		if err != nil {
 			return nil, err
 		}
-
-		if shouldStartCollecting {
-			blocks = append(blocks, blockFs.Value)
-			continue
+		if !ok {
+			return blocks, nil
 		}
-
-		if blockHash == blockFs.Key {
-			shouldStartCollecting = true
+		from = block.Header.Number + 1
+	}
+	for height := from; height <= latest; height++ {
+		block, ok, err := s.store.GetByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			blocks = append(blocks, block)
 		}
 	}
-
 	return blocks, nil
 }
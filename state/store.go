@@ -0,0 +1,339 @@
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+/*
+ BlockStore persists blocks and indexes them by hash and by height
+*/
+type BlockStore interface {
+	// Put appends the block to the store and indexes it
+	Put(b Block) (Hash, error)
+	// Get returns the block with the given hash
+	Get(hash Hash) (Block, bool, error)
+	// GetByHeight returns the block at the given height
+	GetByHeight(height uint64) (Block, bool, error)
+	// GetTxLocation returns the hash of the block containing the tx and the tx's index within it
+	GetTxLocation(txHash Hash) (Hash, int, bool)
+	// HashAt returns the hash of the block at the given height
+	HashAt(height uint64) (Hash, bool)
+	// Has returns true if a block with the given hash is in the store
+	Has(hash Hash) bool
+	// Len returns the number of blocks in the store
+	Len() int
+	// ForEach calls fn with each block in the order they were written
+	ForEach(fn func(BlockFS) error) error
+	// ForEachHeader calls fn with the hash and header of each block in the order they were written
+	ForEachHeader(fn func(Hash, BlockHeader) error) error
+	// SetCanonical makes the block with the given hash the canonical block at its height
+	SetCanonical(hash Hash) error
+	// TrimCanonical removes the canonical blocks above the given height from the height index
+	TrimCanonical(height uint64)
+	Close() error
+}
+
+// location of a tx within a block
+type txLocation struct {
+	block Hash
+	index int
+}
+
+// location of a single BlockFS record within block.db
+type blockRecord struct {
+	offset int64
+	length int
+	header BlockHeader
+}
+
+/*
+ fileBlockStore keeps the JSON-lines block.db format and holds a
+ hash->offset and height->hash index in memory
+*/
+type fileBlockStore struct {
+	mu      sync.RWMutex
+	path    string
+	file    *os.File
+	size    int64
+	order   []Hash
+	records map[Hash]blockRecord
+	heights map[uint64]Hash
+	txs     map[Hash]txLocation
+}
+
+/*
+ Open the block store at 'path', building the indexes from the records on disk.
+ A torn or corrupt record at the tail of the file (e.g. from a crash mid-write)
+ is truncated so the store continues from the last valid block.
+*/
+func NewFileBlockStore(path string) (BlockStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	store := &fileBlockStore{
+		path:    path,
+		file:    f,
+		order:   make([]Hash, 0),
+		records: make(map[Hash]blockRecord),
+		heights: make(map[uint64]Hash),
+		txs:     make(map[Hash]txLocation),
+	}
+	if err := store.buildIndex(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *fileBlockStore) buildIndex() error {
+	reader := bufio.NewReader(s.file)
+	offset := int64(0)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(line) == 0 {
+			break
+		}
+		var blockFs BlockFS
+		isTorn := line[len(line)-1] != '\n'
+		if isTorn || json.Unmarshal(line, &blockFs) != nil {
+			if _, peekErr := reader.Peek(1); !isTorn && peekErr != io.EOF {
+				return fmt.Errorf("corrupt block record at offset %d", offset)
+			}
+			logrus.Warnf("Discarding corrupt block record at the tail of %s (offset %d)\n", s.path, offset)
+			return s.truncate(offset)
+		}
+		s.index(blockFs, offset, len(line))
+		offset += int64(len(line))
+	}
+	s.size = offset
+	return nil
+}
+
+/*
+ Replace the block file with its first 'size' bytes.
+ The valid prefix is written to block.db.tmp and renamed over block.db so a
+ crash during the repair leaves either the old or the repaired file in place.
+*/
+func (s *fileBlockStore) truncate(size int64) error {
+	tmpPath := s.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, io.NewSectionReader(s.file, 0, size)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := Rename(tmpPath, s.path); err != nil {
+		return err
+	}
+	if err := syncDir(filepath.Dir(s.path)); err != nil {
+		return err
+	}
+	s.file.Close()
+	f, err := os.OpenFile(s.path, os.O_RDWR, 0600)
+	if err != nil {
+		return err
+	}
+	s.file = f
+	s.size = size
+	return nil
+}
+
+func (s *fileBlockStore) index(blockFs BlockFS, offset int64, length int) {
+	s.records[blockFs.Key] = blockRecord{offset, length, blockFs.Value.Header}
+	s.order = append(s.order, blockFs.Key)
+	s.indexCanonical(blockFs.Key, blockFs.Value)
+}
+
+func (s *fileBlockStore) indexCanonical(hash Hash, b Block) {
+	s.heights[b.Header.Number] = hash
+	for i, tx := range b.TXs {
+		if txHash, err := tx.Hash(); err == nil {
+			s.txs[txHash] = txLocation{hash, i}
+		}
+	}
+}
+
+func (s *fileBlockStore) Put(b Block) (Hash, error) {
+	blockHash, err := b.Hash()
+	if err != nil {
+		return Hash{}, err
+	}
+	blockFs := BlockFS{blockHash, b}
+	blockFsJSON, err := json.Marshal(blockFs)
+	if err != nil {
+		return Hash{}, err
+	}
+	blockFsJSON = append(blockFsJSON, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.records[blockHash]; exists {
+		return blockHash, nil
+	}
+	// the record is only indexed once it is durable on disk, a partial
+	// write is rolled back so the file always ends on a record boundary
+	if _, err := s.file.WriteAt(blockFsJSON, s.size); err != nil {
+		s.file.Truncate(s.size)
+		return Hash{}, err
+	}
+	if err := s.file.Sync(); err != nil {
+		s.file.Truncate(s.size)
+		return Hash{}, err
+	}
+	s.index(blockFs, s.size, len(blockFsJSON))
+	s.size += int64(len(blockFsJSON))
+	return blockHash, nil
+}
+
+func (s *fileBlockStore) Get(hash Hash) (Block, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	record, ok := s.records[hash]
+	if !ok {
+		return Block{}, false, nil
+	}
+	blockFs, err := s.read(record)
+	if err != nil {
+		return Block{}, false, err
+	}
+	return blockFs.Value, true, nil
+}
+
+func (s *fileBlockStore) GetByHeight(height uint64) (Block, bool, error) {
+	hash, ok := s.HashAt(height)
+	if !ok {
+		return Block{}, false, nil
+	}
+	return s.Get(hash)
+}
+
+func (s *fileBlockStore) GetTxLocation(txHash Hash) (Hash, int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	location, ok := s.txs[txHash]
+	if !ok || s.heights[s.records[location.block].header.Number] != location.block {
+		// the tx is only in a block that is no longer canonical
+		return Hash{}, 0, false
+	}
+	return location.block, location.index, true
+}
+
+func (s *fileBlockStore) HashAt(height uint64) (Hash, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hash, ok := s.heights[height]
+	return hash, ok
+}
+
+func (s *fileBlockStore) Has(hash Hash) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.records[hash]
+	return ok
+}
+
+func (s *fileBlockStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.order)
+}
+
+func (s *fileBlockStore) ForEach(fn func(BlockFS) error) error {
+	s.mu.RLock()
+	order := make([]Hash, len(s.order))
+	copy(order, s.order)
+	s.mu.RUnlock()
+	for _, hash := range order {
+		s.mu.RLock()
+		blockFs, err := s.read(s.records[hash])
+		s.mu.RUnlock()
+		if err != nil {
+			return err
+		}
+		if err := fn(blockFs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fileBlockStore) ForEachHeader(fn func(Hash, BlockHeader) error) error {
+	s.mu.RLock()
+	order := make([]Hash, len(s.order))
+	copy(order, s.order)
+	s.mu.RUnlock()
+	for _, hash := range order {
+		s.mu.RLock()
+		header := s.records[hash].header
+		s.mu.RUnlock()
+		if err := fn(hash, header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fileBlockStore) SetCanonical(hash Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[hash]
+	if !ok {
+		return fmt.Errorf("block '%x' is not in the store", hash)
+	}
+	if s.heights[record.header.Number] == hash {
+		return nil
+	}
+	blockFs, err := s.read(record)
+	if err != nil {
+		return err
+	}
+	s.indexCanonical(hash, blockFs.Value)
+	return nil
+}
+
+func (s *fileBlockStore) TrimCanonical(height uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for number := range s.heights {
+		if number > height {
+			delete(s.heights, number)
+		}
+	}
+}
+
+func (s *fileBlockStore) Close() error {
+	return s.file.Close()
+}
+
+func (s *fileBlockStore) read(record blockRecord) (BlockFS, error) {
+	buf := make([]byte, record.length)
+	if _, err := s.file.ReadAt(buf, record.offset); err != nil {
+		return BlockFS{}, err
+	}
+	var blockFs BlockFS
+	if err := json.Unmarshal(buf, &blockFs); err != nil {
+		return BlockFS{}, err
+	}
+	return blockFs, nil
+}
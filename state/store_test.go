@@ -0,0 +1,88 @@
+package state
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FileBlockStore_PutAndGet(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "store_test")
+	assert.Nil(t, err)
+	defer RemoveDir(tmpDir)
+	path := filepath.Join(tmpDir, "block.db")
+
+	store, err := NewFileBlockStore(path)
+	assert.Nil(t, err)
+	first := NewBlock(Hash{}, Hash{}, Hash{}, 1, 1, DefaultDifficulty, nil, 1, NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD"), 1)
+	firstHash, err := store.Put(first)
+	assert.Nil(t, err)
+	second := NewBlock(firstHash, Hash{}, Hash{}, 2, 2, DefaultDifficulty, nil, 2, NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD"), 1)
+	secondHash, err := store.Put(second)
+	assert.Nil(t, err)
+
+	block, ok, err := store.Get(secondHash)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, second, block)
+
+	hash, ok := store.HashAt(1)
+	assert.True(t, ok)
+	assert.Equal(t, firstHash, hash)
+	assert.Nil(t, store.Close())
+
+	// the indexes are rebuilt when the store is reopened
+	store, err = NewFileBlockStore(path)
+	assert.Nil(t, err)
+	defer store.Close()
+	assert.Equal(t, 2, store.Len())
+	block, ok, err = store.GetByHeight(2)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, second, block)
+	_, ok, err = store.Get(Hash{})
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func Test_FileBlockStore_RepairsTornTail(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "store_test")
+	assert.Nil(t, err)
+	defer RemoveDir(tmpDir)
+	path := filepath.Join(tmpDir, "block.db")
+
+	store, err := NewFileBlockStore(path)
+	assert.Nil(t, err)
+	block := NewBlock(Hash{}, Hash{}, Hash{}, 1, 1, DefaultDifficulty, nil, 1, NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD"), 1)
+	blockHash, err := store.Put(block)
+	assert.Nil(t, err)
+	assert.Nil(t, store.Close())
+	valid, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+
+	// simulate a crash half way through writing the next record
+	torn := append(append([]byte{}, valid...), valid[:len(valid)/2]...)
+	assert.Nil(t, ioutil.WriteFile(path, torn, 0600))
+
+	store, err = NewFileBlockStore(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, store.Len())
+	assert.True(t, store.Has(blockHash))
+	assert.Nil(t, store.Close())
+	repaired, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, valid, repaired)
+}
+
+func Test_FileBlockStore_CorruptRecordBeforeTail(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "store_test")
+	assert.Nil(t, err)
+	defer RemoveDir(tmpDir)
+	path := filepath.Join(tmpDir, "block.db")
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte("{not json}\n{\"hash\":\"\"}\n"), 0600))
+	_, err = NewFileBlockStore(path)
+	assert.NotNil(t, err)
+}
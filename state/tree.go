@@ -0,0 +1,146 @@
+package state
+
+import (
+	"math/big"
+	"sync"
+)
+
+// chainNode is a block in the block tree
+type chainNode struct {
+	hash      Hash
+	header    BlockHeader
+	totalWork *big.Int
+	// the order the block was added to the tree in, which breaks ties between equal work branches
+	seen uint64
+	// set while the block is on a side branch and not yet persisted
+	block *Block
+}
+
+/*
+ blockTree tracks every known block, including competing branches, so the
+ canonical chain can be chosen as the branch with the most accumulated work.
+ The empty hash is the root of the tree (the parent of block 1).
+*/
+type blockTree struct {
+	// guards the nodes and their blocks. The tree is changed under the state's lock,
+	// but the consensus engines and the miner read headers from it without it
+	mu    sync.RWMutex
+	nodes map[Hash]*chainNode
+	tip   *chainNode
+	added uint64
+}
+
+func newBlockTree() *blockTree {
+	root := &chainNode{hash: Hash{}, totalWork: big.NewInt(0)}
+	return &blockTree{nodes: map[Hash]*chainNode{Hash{}: root}, tip: root}
+}
+
+/*
+ Add the block, and the work it adds to its branch, to the tree.
+ The block's parent must already be in the tree.
+*/
+func (t *blockTree) add(hash Hash, header BlockHeader, work *big.Int, block *Block) *chainNode {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if node, ok := t.nodes[hash]; ok {
+		return node
+	}
+	parent := t.nodes[header.Parent]
+	totalWork := new(big.Int).Add(parent.totalWork, work)
+	t.added++
+	node := &chainNode{hash, header, totalWork, t.added, block}
+	t.nodes[hash] = node
+	return node
+}
+
+func (t *blockTree) get(hash Hash) (*chainNode, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	node, ok := t.nodes[hash]
+	return node, ok
+}
+
+/*
+ The block of a side branch node, held in memory until it is persisted
+*/
+func (t *blockTree) block(hash Hash) (Block, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	node, ok := t.nodes[hash]
+	if !ok || node.block == nil {
+		return Block{}, false
+	}
+	return *node.block, true
+}
+
+/*
+ Drop the block held in memory for the node once it is persisted
+*/
+func (t *blockTree) persisted(node *chainNode) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node.block = nil
+}
+
+/*
+ Remove the block and all of its descendants from the tree
+*/
+func (t *blockTree) remove(hash Hash) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.removeLocked(hash)
+}
+
+func (t *blockTree) removeLocked(hash Hash) {
+	delete(t.nodes, hash)
+	for childHash, node := range t.nodes {
+		if node.header.Parent == hash && childHash != (Hash{}) {
+			t.removeLocked(childHash)
+		}
+	}
+}
+
+/*
+ Return the node with the most accumulated work, preferring the current tip on a tie
+ and otherwise the node added first, so the blocks of the store always pick the same tip
+*/
+func (t *blockTree) best() *chainNode {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	best := t.tip
+	for _, node := range t.nodes {
+		cmp := node.totalWork.Cmp(best.totalWork)
+		if cmp > 0 || (cmp == 0 && best != t.tip && node.seen < best.seen) {
+			best = node
+		}
+	}
+	return best
+}
+
+/*
+ Walk back from 'from' and 'to' to their common ancestor. Returns the ancestor,
+ the nodes from 'from' back to the ancestor (newest first) and the nodes from
+ the ancestor to 'to' (oldest first).
+*/
+func (t *blockTree) path(from *chainNode, to *chainNode) (*chainNode, []*chainNode, []*chainNode) {
+	detached := make([]*chainNode, 0)
+	attached := make([]*chainNode, 0)
+	for from.header.Number > to.header.Number {
+		detached = append(detached, from)
+		from = t.nodes[from.header.Parent]
+	}
+	for to.header.Number > from.header.Number {
+		attached = append(attached, to)
+		to = t.nodes[to.header.Parent]
+	}
+	for from.hash != to.hash {
+		detached = append(detached, from)
+		attached = append(attached, to)
+		from = t.nodes[from.header.Parent]
+		to = t.nodes[to.header.Parent]
+	}
+	for i, j := 0, len(attached)-1; i < j; i, j = i+1, j-1 {
+		attached[i], attached[j] = attached[j], attached[i]
+	}
+	return from, detached, attached
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/elliptic"
 	"crypto/sha256"
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -14,6 +16,20 @@ func NewAddress(value string) common.Address {
 	return common.HexToAddress(value)
 }
 
+// TxType says what a tx does, each type carries its own payload
+type TxType string
+
+const (
+	// creates a channel named by the Topic
+	TxTypeTopic TxType = "topic"
+	// votes a proof of authority signer in or out
+	TxTypeVote TxType = "vote"
+	// mints a block's reward
+	TxTypeCoinbase TxType = "coinbase"
+	// sends tokens to another account
+	TxTypeTransfer TxType = "transfer"
+)
+
 type Tx struct {
 	Author common.Address `json:"author"`
 	Topic  string         `json:"topic"`
@@ -21,6 +37,31 @@ type Tx struct {
 	// ValidationRules ???
 	Nonce uint   `json:"nonce"`
 	Time  uint64 `json:"time"`
+	// set on txs that vote a proof of authority signer in or out
+	Vote *SignerVote `json:"vote,omitempty"`
+	// set on the coinbase tx that mints a block's reward
+	Coinbase *Coinbase `json:"coinbase,omitempty"`
+	// the type of the tx, txs created before txs were typed have none and their type
+	// follows from their payload
+	Type TxType `json:"type,omitempty"`
+	// set on txs that send tokens
+	Transfer *Transfer `json:"transfer,omitempty"`
+	// paid by the author to the miner of the block that includes the tx, in base units
+	Fee uint64 `json:"fee,omitempty"`
+	// the chain the tx was signed for, so it can't be replayed on another chain
+	ChainID string `json:"chain_id,omitempty"`
+}
+
+// SignerVote is a signer's vote to authorize or remove a proof of authority signer
+type SignerVote struct {
+	Signer    common.Address `json:"signer"`
+	Authorize bool           `json:"authorize"`
+}
+
+// Transfer sends Amount base units from the tx author to To
+type Transfer struct {
+	To     common.Address `json:"to"`
+	Amount uint64         `json:"amount"`
 }
 
 type SignedTx struct {
@@ -29,13 +70,91 @@ type SignedTx struct {
 }
 
 func NewTx(from common.Address, topic string, nonce uint) Tx {
-	return Tx{from, topic, nonce, uint64(time.Now().Unix())}
+	return Tx{from, topic, nonce, uint64(time.Now().Unix()), nil, nil, TxTypeTopic, nil, 0, ""}
+}
+
+func NewVoteTx(from common.Address, signer common.Address, authorize bool, nonce uint) Tx {
+	return Tx{from, "", nonce, uint64(time.Now().Unix()), &SignerVote{signer, authorize}, nil, TxTypeVote, nil, 0, ""}
+}
+
+func NewTransferTx(from common.Address, to common.Address, amount uint64, nonce uint) Tx {
+	return Tx{from, "", nonce, uint64(time.Now().Unix()), nil, nil, TxTypeTransfer, &Transfer{to, amount}, 0, ""}
 }
 
 func NewSignedTx(tx Tx, sig []byte) SignedTx {
 	return SignedTx{tx, sig}
 }
 
+/*
+ The type of the tx. Untyped txs predate typed txs and are typed by their payload.
+*/
+func (t Tx) TxType() TxType {
+	if t.Type != "" {
+		return t.Type
+	}
+	switch {
+	case t.Coinbase != nil:
+		return TxTypeCoinbase
+	case t.Vote != nil:
+		return TxTypeVote
+	default:
+		return TxTypeTopic
+	}
+}
+
+/*
+ Check that the tx carries the payload of its type and no other
+*/
+func (t Tx) ValidatePayload() error {
+	txType := t.TxType()
+	has := map[TxType]bool{
+		TxTypeVote:     t.Vote != nil,
+		TxTypeCoinbase: t.Coinbase != nil,
+		TxTypeTransfer: t.Transfer != nil,
+	}
+	if _, ok := has[txType]; !ok && txType != TxTypeTopic {
+		return fmt.Errorf("unknown tx type '%s'", txType)
+	}
+	for payloadType, present := range has {
+		if present != (payloadType == txType) {
+			return fmt.Errorf("a '%s' tx can't have a '%s' payload", txType, payloadType)
+		}
+	}
+	if txType == TxTypeTransfer && t.Transfer.Amount == 0 {
+		return fmt.Errorf("a transfer must send a positive amount")
+	}
+	return nil
+}
+
+/*
+ The tx with the given fee
+*/
+func (t Tx) WithFee(fee uint64) Tx {
+	t.Fee = fee
+	return t
+}
+
+/*
+ The amount the author pays for the tx: its fee and its value
+*/
+func (t Tx) Cost() uint64 {
+	return saturatingAdd(t.Fee, t.Value())
+}
+
+/*
+ The amount the tx spends besides its fee, the price of a channel or the tokens sent by a transfer
+*/
+func (t Tx) Value() uint64 {
+	switch t.TxType() {
+	case TxTypeTopic:
+		return TopicCost
+	case TxTypeTransfer:
+		return t.Transfer.Amount
+	default:
+		return 0
+	}
+}
+
 func (t Tx) Hash() (Hash, error) {
 	txJson, err := json.Marshal(t)
 	if err != nil {
@@ -49,7 +168,14 @@ func (t Tx) Encode() ([]byte, error) {
 	return json.Marshal(t)
 }
 
-func (t SignedTx) IsAuthentic() (bool, error) {
+/*
+ Check the tx is signed by its author for the chain with the given ID. The chain ID
+ is part of the signed hash, so a tx signed for one chain is never authentic on another.
+*/
+func (t SignedTx) IsAuthentic(chainID string) (bool, error) {
+	if t.ChainID != chainID {
+		return false, fmt.Errorf("tx is signed for chain '%s' not '%s'", t.ChainID, chainID)
+	}
 	txHash, err := t.Tx.Hash()
 	if err != nil {
 		return false, err
@@ -66,3 +192,41 @@ func (t SignedTx) IsAuthentic() (bool, error) {
 
 	return recoveredAccount.Hex() == t.Author.Hex(), nil
 }
+
+/*
+ Compute the root of the Merkle tree over the hashes of the txs, in block order
+*/
+func TxRoot(txs []SignedTx) (Hash, error) {
+	leaves, err := txHashes(txs)
+	if err != nil {
+		return Hash{}, err
+	}
+	return MerkleRoot(leaves), nil
+}
+
+func txHashes(txs []SignedTx) ([]Hash, error) {
+	hashes := make([]Hash, len(txs))
+	for i, tx := range txs {
+		txHash, err := tx.Hash()
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = txHash
+	}
+	return hashes, nil
+}
+
+/*
+ Parse an amount of coins, e.g. "1.5", into base units
+*/
+func ParseCoins(value string) (uint64, error) {
+	coins, ok := new(big.Rat).SetString(value)
+	if !ok || coins.Sign() < 0 {
+		return 0, fmt.Errorf("invalid amount '%s'", value)
+	}
+	units := coins.Mul(coins, new(big.Rat).SetInt(new(big.Int).SetUint64(CoinUnit)))
+	if !units.IsInt() || !units.Num().IsUint64() {
+		return 0, fmt.Errorf("amount '%s' must be a whole number of base units that fits in 64 bits", value)
+	}
+	return units.Num().Uint64(), nil
+}
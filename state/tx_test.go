@@ -0,0 +1,154 @@
+package state
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func signTestTx(t *testing.T, tx Tx, signFn SignerFn) SignedTx {
+	txJSON, err := tx.Encode()
+	assert.Nil(t, err)
+	sig, err := signFn(txJSON)
+	assert.Nil(t, err)
+	return NewSignedTx(tx, sig)
+}
+
+func Test_Tx_TxType(t *testing.T) {
+	author := NewAddress("0x851EAF29553B0CE3180AC08c221050E2295D14cD")
+	// txs from before txs were typed are typed by their payload
+	assert.Equal(t, TxTypeTopic, Tx{Author: author, Topic: "legacy"}.TxType())
+	assert.Equal(t, TxTypeVote, Tx{Author: author, Vote: &SignerVote{}}.TxType())
+	assert.Equal(t, TxTypeCoinbase, Tx{Coinbase: &Coinbase{}}.TxType())
+
+	transfer := NewTransferTx(author, author, 5, 1)
+	assert.Equal(t, TxTypeTransfer, transfer.TxType())
+	assert.Nil(t, transfer.ValidatePayload())
+	assert.Equal(t, uint64(5), transfer.Cost())
+
+	// the payload must match the type
+	transfer.Vote = &SignerVote{}
+	assert.NotNil(t, transfer.ValidatePayload())
+	assert.NotNil(t, Tx{Type: TxTypeTransfer}.ValidatePayload())
+	assert.NotNil(t, NewTransferTx(author, author, 0, 1).ValidatePayload())
+	assert.NotNil(t, Tx{Type: "mint"}.ValidatePayload())
+}
+
+func Test_ApplyTx_Transfer(t *testing.T) {
+	from, signFn := newTestSigner(t)
+	to := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	s := &State{
+		Catalog:       map[common.Address]CurrentNodeState{from: {Balance: 10}},
+		Account2Nonce: make(map[common.Address]uint),
+	}
+
+	assert.Nil(t, applyTx(1, signTestTx(t, NewTransferTx(from, to, 4, 1), signFn), s))
+	assert.Equal(t, uint64(6), s.Catalog[from].Balance)
+	assert.Equal(t, uint64(4), s.Catalog[to].Balance)
+	assert.Equal(t, uint(1), s.Account2Nonce[from])
+
+	// a transfer of more than the balance is rejected and changes nothing
+	assert.NotNil(t, applyTx(1, signTestTx(t, NewTransferTx(from, to, 7, 2), signFn), s))
+	assert.Equal(t, uint64(6), s.Catalog[from].Balance)
+	assert.Equal(t, uint(1), s.Account2Nonce[from])
+}
+
+func Test_ParseCoins(t *testing.T) {
+	amount, err := ParseCoins("1.5")
+	assert.Nil(t, err)
+	assert.Equal(t, 3*CoinUnit/2, amount)
+	amount, err = ParseCoins("0.00000001")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), amount)
+
+	_, err = ParseCoins("0.000000001")
+	assert.NotNil(t, err)
+	_, err = ParseCoins("-1")
+	assert.NotNil(t, err)
+	_, err = ParseCoins("ten")
+	assert.NotNil(t, err)
+}
+
+func Test_ApplyTx_ChainID(t *testing.T) {
+	from, signFn := newTestSigner(t)
+	to := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	s := &State{
+		Catalog:       map[common.Address]CurrentNodeState{from: {Balance: 10}},
+		Account2Nonce: make(map[common.Address]uint),
+		genesis:       Genesis{ChainID: "mercury-test"},
+	}
+
+	// a tx signed for another chain, or for no chain, is rejected
+	tx := NewTransferTx(from, to, 1, 1)
+	assert.NotNil(t, applyTx(1, signTestTx(t, tx, signFn), s))
+	tx.ChainID = "other-chain"
+	assert.NotNil(t, applyTx(1, signTestTx(t, tx, signFn), s))
+	assert.Equal(t, uint64(10), s.Catalog[from].Balance)
+
+	tx.ChainID = "mercury-test"
+	assert.Nil(t, applyTx(1, signTestTx(t, tx, signFn), s))
+	assert.Equal(t, uint64(9), s.Catalog[from].Balance)
+}
+
+/*
+ Build the next block of a dev chain with the given txs, after its coinbase
+*/
+func buildTestBlock(t *testing.T, s *State, miner common.Address, txs ...SignedTx) Block {
+	if reward, ok := s.NextBlockReward(); ok {
+		payouts := SplitReward(reward, []RewardShare{{To: miner, Weight: 1}})
+		txs = append([]SignedTx{NewCoinbaseTx(s.NextBlockNumber(), payouts)}, txs...)
+	}
+	stateRoot, err := s.NextStateRoot(miner, txs)
+	assert.Nil(t, err)
+	txRoot, err := TxRoot(txs)
+	assert.Nil(t, err)
+	return NewBlock(s.LatestBlockHash(), stateRoot, txRoot, uint64(time.Now().Unix()), s.NextBlockNumber(),
+		devDifficulty, txs, 0, miner, 0)
+}
+
+func Test_ReplayBlocksWithoutChainID(t *testing.T) {
+	datadir, err := ioutil.TempDir("", "tx_test")
+	assert.Nil(t, err)
+	defer RemoveDir(datadir)
+	from, signFn := newTestSigner(t)
+	to := NewAddress("0x96131b31b9935f6388502b502cf544c1a8c65ad6")
+	genesis := NewDevGenesis(from)
+	genesis.ChainIDFork = 1
+	assert.Nil(t, InitDataDir(datadir, genesis))
+
+	// a block mined before txs were signed for a chain, its tx has no chain ID
+	s, err := NewStateFromDisk(datadir)
+	assert.Nil(t, err)
+	legacy := buildTestBlock(t, s, from, signTestTx(t, NewTransferTx(from, to, 1, 1), signFn))
+	_, err = s.AddBlock(legacy)
+	assert.Nil(t, err)
+	s.Close()
+
+	// it replays when the node restarts
+	s, err = NewStateFromDisk(datadir)
+	assert.Nil(t, err)
+	defer s.Close()
+	assert.Equal(t, uint64(1), s.Catalog[to].Balance)
+
+	// blocks after the fork height must sign their txs for the chain. The block is built
+	// on a copy of the state with a later fork height, as a node with another genesis would
+	ahead := s.Copy()
+	ahead.genesis.ChainIDFork = 2
+	late := buildTestBlock(t, ahead, from, signTestTx(t, NewTransferTx(from, to, 1, 2), signFn))
+	_, err = s.AddBlock(late)
+	assert.NotNil(t, err)
+
+	// a chain whose genesis has no fork height rejects the block without a chain ID
+	fresh, err := ioutil.TempDir("", "tx_test")
+	assert.Nil(t, err)
+	defer RemoveDir(fresh)
+	assert.Nil(t, InitDataDir(fresh, NewDevGenesis(from)))
+	other, err := NewStateFromDisk(fresh)
+	assert.Nil(t, err)
+	defer other.Close()
+	_, err = other.AddBlock(legacy)
+	assert.NotNil(t, err)
+}
@@ -52,6 +52,19 @@ func NewKeystoreAccount(dataDir, password string) (common.Address, error) {
 	return acc.Address, nil
 }
 
+/*
+ The account used in dev mode: the first account in the keystore, or a new account
+ with an empty password if the keystore is empty. True if the account was created.
+*/
+func DevAccount(dataDir string) (common.Address, bool, error) {
+	ks := keystore.NewKeyStore(GetKeystoreDirPath(dataDir), keystore.StandardScryptN, keystore.StandardScryptP)
+	if accs := ks.Accounts(); len(accs) > 0 {
+		return accs[0].Address, false, nil
+	}
+	account, err := NewKeystoreAccount(dataDir, "")
+	return account, true, err
+}
+
 // GetEncryptionPublicKey returns user's public Encryption key derived from privateKey Ethereum key
 func GetEncryptionPublicKey(receiverAddress string) string {
 	privateKey0, _ := hexutil.Decode("0x" + receiverAddress)
@@ -127,7 +140,7 @@ func Decrypt(password string, keystoredir string, encryptedData EncryptedData) (
 	}
 }
 
-func SignTxWithKeystoreAccount(tx state.Tx, address common.Address, pwd, keystoreDir string) (state.SignedTx, error) {
+func SignTxWithKeystoreAccount(tx state.Tx, chainID string, address common.Address, pwd, keystoreDir string) (state.SignedTx, error) {
 	keystoreJSON, err := recoverKeystoreJSON(keystoreDir, address)
 	if err != nil {
 		return state.SignedTx{}, err
@@ -137,7 +150,7 @@ func SignTxWithKeystoreAccount(tx state.Tx, address common.Address, pwd, keystor
 		return state.SignedTx{}, err
 	}
 
-	signedTx, err := SignTx(tx, key.PrivateKey)
+	signedTx, err := SignTx(tx, chainID, key.PrivateKey)
 	if err != nil {
 		return state.SignedTx{}, err
 	}
@@ -145,6 +158,24 @@ func SignTxWithKeystoreAccount(tx state.Tx, address common.Address, pwd, keystor
 	return signedTx, nil
 }
 
+/*
+ Unlock the keystore account once and return a function that signs with its key,
+ e.g. for sealing proof of authority blocks
+*/
+func NewKeystoreSignerFn(address common.Address, pwd, keystoreDir string) (state.SignerFn, error) {
+	keystoreJSON, err := recoverKeystoreJSON(keystoreDir, address)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(keystoreJSON, pwd)
+	if err != nil {
+		return nil, err
+	}
+	return func(msg []byte) ([]byte, error) {
+		return Sign(msg, key.PrivateKey)
+	}, nil
+}
+
 func recoverKeystoreJSON(keystoreDir string, address common.Address) ([]byte, error) {
 	ks := keystore.NewKeyStore(keystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
 	ksAccount, err := ks.Find(accounts.Account{Address: address})
@@ -159,7 +190,9 @@ func recoverKeystoreJSON(keystoreDir string, address common.Address) ([]byte, er
 	return ksAccountJSON, nil
 }
 
-func SignTx(tx state.Tx, privKey *ecdsa.PrivateKey) (state.SignedTx, error) {
+func SignTx(tx state.Tx, chainID string, privKey *ecdsa.PrivateKey) (state.SignedTx, error) {
+	// the chain ID is signed with the tx so the tx is only valid on that chain
+	tx.ChainID = chainID
 	rawTx, err := tx.Encode()
 	if err != nil {
 		return state.SignedTx{}, err
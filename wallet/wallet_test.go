@@ -22,6 +22,8 @@ import (
 // 	./node/test_babayaga--6fdc0d8d15ae6b4ebf45c52fd2aafbcbb19a65c8
 const testKeystoreAccountsPwd = "security123"
 
+const testChainID = "mercury-test"
+
 // Prints a PK:
 //
 // (*ecdsa.PrivateKey)(0xc000099980)({
@@ -130,7 +132,7 @@ func TestSign(t *testing.T) {
 // 		return
 // 	}
 
-// 	ok, err := signedTx.IsAuthentic()
+// 	ok, err := signedTx.IsAuthentic(testChainID)
 // 	if err != nil {
 // 		t.Error(err)
 // 		return
@@ -162,13 +164,13 @@ func TestSignForgedTxWithKeystoreAccount(t *testing.T) {
 
 	forgedTx := state.NewTx(babaYaga, "Test", 1)
 
-	signedTx, err := SignTxWithKeystoreAccount(forgedTx, hacker, testKeystoreAccountsPwd, GetKeystoreDirPath(tmpDir))
+	signedTx, err := SignTxWithKeystoreAccount(forgedTx, testChainID, hacker, testKeystoreAccountsPwd, GetKeystoreDirPath(tmpDir))
 	if err != nil {
 		t.Error(err)
 		return
 	}
 
-	ok, err := signedTx.IsAuthentic()
+	ok, err := signedTx.IsAuthentic(testChainID)
 	if err != nil {
 		t.Error(err)
 		return
@@ -190,3 +192,23 @@ func Test_Encrypt(t *testing.T) {
 	}
 	assert.NotNil(t, encrypted)
 }
+
+func TestSignTx_ChainID(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	assert.Nil(t, err)
+	author := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	signedTx, err := SignTx(state.NewTx(author, "Test", 1), testChainID, privKey)
+	assert.Nil(t, err)
+	assert.Equal(t, testChainID, signedTx.ChainID)
+	ok, err := signedTx.IsAuthentic(testChainID)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// the tx can't be replayed on another chain, not even by changing its chain ID
+	_, err = signedTx.IsAuthentic("other-chain")
+	assert.NotNil(t, err)
+	signedTx.ChainID = "other-chain"
+	ok, _ = signedTx.IsAuthentic("other-chain")
+	assert.False(t, ok)
+}